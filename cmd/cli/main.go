@@ -318,7 +318,7 @@ func runVM(
 	var segmentsOffsets []uint64
 	var relocatedMemory []*fp.Element
 	if proofmode || buildMemory {
-		relocatedMemory, segmentsOffsets = cairoRunner.BuildMemory()
+		relocatedMemory, segmentsOffsets, err = cairoRunner.BuildMemory()
 		if err != nil {
 			return fmt.Errorf("cannot build memory: %w", err)
 		}
@@ -2,7 +2,10 @@ package core
 
 import (
 	"fmt"
+	"io"
 	"math/big"
+	"math/bits"
+	"os"
 	"sort"
 
 	"github.com/holiman/uint256"
@@ -16,6 +19,7 @@ import (
 	mem "github.com/NethermindEth/cairo-vm-go/pkg/vm/memory"
 	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
 )
 
 func GetCairoHints(cairoProgramJson *starknet.StarknetProgram) (map[uint64][]hinter.Hinter, error) {
@@ -58,6 +62,70 @@ func (hint *AllocSegment) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerCon
 	return nil
 }
 
+type AllocSegmentWithCapacity struct {
+	Size hinter.Reference
+	Dst  hinter.Reference
+}
+
+func (hint *AllocSegmentWithCapacity) String() string {
+	return "AllocSegmentWithCapacity"
+}
+
+func (hint *AllocSegmentWithCapacity) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	_, _, err := allocSegmentWithCapacity(vm, hint.Size, hint.Dst)
+	return err
+}
+
+// Allocates a segment with room for the number of cells given by size, which is bounded
+// by hinter.MaxLength, and writes its address to dst. Returns the segment address and size
+func allocSegmentWithCapacity(vm *VM.VirtualMachine, size, dst hinter.Reference) (mem.MemoryAddress, uint64, error) {
+	capacity, err := hinter.ResolveAsLength(vm, size)
+	if err != nil {
+		return mem.UnknownAddress, 0, fmt.Errorf("resolve size: %w", err)
+	}
+
+	newSegment := vm.Memory.AllocateEmptySegmentWithCapacity(int(capacity))
+	memAddress := mem.MemoryValueFromMemoryAddress(&newSegment)
+
+	regAddr, err := dst.Get(vm)
+	if err != nil {
+		return mem.UnknownAddress, 0, fmt.Errorf("get register %s: %w", dst, err)
+	}
+
+	err = vm.Memory.WriteToAddress(&regAddr, &memAddress)
+	if err != nil {
+		return mem.UnknownAddress, 0, fmt.Errorf("write to address %s: %w", regAddr, err)
+	}
+
+	return newSegment, capacity, nil
+}
+
+// AllocZeroedSegment allocates a new segment holding Size zero felts and writes its
+// base address to Dst
+type AllocZeroedSegment struct {
+	Size hinter.Reference
+	Dst  hinter.Reference
+}
+
+func (hint *AllocZeroedSegment) String() string {
+	return "AllocZeroedSegment"
+}
+
+func (hint *AllocZeroedSegment) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	newSegment, size, err := allocSegmentWithCapacity(vm, hint.Size, hint.Dst)
+	if err != nil {
+		return err
+	}
+
+	zero := mem.MemoryValueFromInt(0)
+	for i := uint64(0); i < size; i++ {
+		if err := vm.Memory.Write(newSegment.SegmentIndex, i, &zero); err != nil {
+			return fmt.Errorf("write zero at offset %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 type EvalCircuit struct {
 	AddModN   hinter.Reference
 	AddModPtr hinter.Reference
@@ -95,9 +163,33 @@ func (hint *EvalCircuit) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerCont
 		return err
 	}
 
+	if nAddModsFelt > 0 {
+		if err := checkModBuiltinInputs(vm, *addModInputAddress); err != nil {
+			return fmt.Errorf("AddMod builtin: %w", err)
+		}
+	}
+	if nMulModsFelt > 0 {
+		if err := checkModBuiltinInputs(vm, *mulModInputAddress); err != nil {
+			return fmt.Errorf("MulMod builtin: %w", err)
+		}
+	}
+
 	return builtins.FillMemory(vm.Memory, *addModInputAddress, nAddModsFelt, *mulModInputAddress, nMulModsFelt)
 }
 
+// Reads the ModBuiltin struct of a mod builtin instance and errors if a field has
+// the wrong kind or if the modulus p is zero
+func checkModBuiltinInputs(vm *VM.VirtualMachine, modBuiltinPtr mem.MemoryAddress) error {
+	modBuiltin, err := readModBuiltinStruct(vm, modBuiltinPtr)
+	if err != nil {
+		return err
+	}
+	if modBuiltin.p.Sign() == 0 {
+		return fmt.Errorf("modulus is zero")
+	}
+	return nil
+}
+
 type TestLessThan struct {
 	dst hinter.Reference
 	lhs hinter.Reference
@@ -119,18 +211,13 @@ func (hint *TestLessThan) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerCon
 		return fmt.Errorf("resolve rhs operand %s: %w", hint.rhs, err)
 	}
 
-	lhsFelt, err := lhsVal.FieldElement()
-	if err != nil {
-		return err
-	}
-
-	rhsFelt, err := rhsVal.FieldElement()
+	cmp, err := lhsVal.Cmp(&rhsVal)
 	if err != nil {
 		return err
 	}
 
 	resFelt := f.Element{}
-	if lhsFelt.Cmp(rhsFelt) < 0 {
+	if cmp < 0 {
 		resFelt.SetOne()
 	}
 
@@ -169,18 +256,13 @@ func (hint *TestLessThanOrEqual) Execute(vm *VM.VirtualMachine, _ *hinter.HintRu
 		return fmt.Errorf("resolve rhs operand %s: %w", hint.rhs, err)
 	}
 
-	lhsFelt, err := lhsVal.FieldElement()
-	if err != nil {
-		return err
-	}
-
-	rhsFelt, err := rhsVal.FieldElement()
+	cmp, err := lhsVal.Cmp(&rhsVal)
 	if err != nil {
 		return err
 	}
 
 	resFelt := f.Element{}
-	if lhsFelt.Cmp(rhsFelt) <= 0 {
+	if cmp <= 0 {
 		resFelt.SetOne()
 	}
 
@@ -334,8 +416,6 @@ func (hint *WideMul128) String() string {
 }
 
 func (hint *WideMul128) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
-	mask := &utils.Uint256Max128
-
 	lhs, err := hint.lhs.Resolve(vm)
 	if err != nil {
 		return fmt.Errorf("resolve lhs operand %s: %w", hint.lhs, err)
@@ -354,16 +434,16 @@ func (hint *WideMul128) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerConte
 		return err
 	}
 
-	lhsU256 := uint256.Int(lhsFelt.Bits())
-	rhsU256 := uint256.Int(rhsFelt.Bits())
-
-	if lhsU256.Gt(mask) {
-		return fmt.Errorf("lhs operand %s should be u128", lhsFelt)
+	if err := u.AssertU128(lhsFelt); err != nil {
+		return fmt.Errorf("lhs operand %w", err)
 	}
-	if rhsU256.Gt(mask) {
-		return fmt.Errorf("rhs operand %s should be u128", rhsFelt)
+	if err := u.AssertU128(rhsFelt); err != nil {
+		return fmt.Errorf("rhs operand %w", err)
 	}
 
+	lhsU256 := uint256.Int(lhsFelt.Bits())
+	rhsU256 := uint256.Int(rhsFelt.Bits())
+
 	mul := lhsU256.Mul(&lhsU256, &rhsU256)
 
 	bytes := mul.Bytes32()
@@ -533,19 +613,19 @@ func (hint Uint256InvModN) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerCo
 		return fmt.Errorf("get TOrK1 address %s: %w", tOrK1Addr, err)
 	}
 
-	B0Felt, err := B0.FieldElement()
+	B0Felt, err := B0.ExpectFelt("B0")
 	if err != nil {
 		return err
 	}
-	B1Felt, err := B1.FieldElement()
+	B1Felt, err := B1.ExpectFelt("B1")
 	if err != nil {
 		return err
 	}
-	N0Felt, err := N0.FieldElement()
+	N0Felt, err := N0.ExpectFelt("N0")
 	if err != nil {
 		return err
 	}
-	N1Felt, err := N1.FieldElement()
+	N1Felt, err := N1.ExpectFelt("N1")
 	if err != nil {
 		return err
 	}
@@ -695,6 +775,43 @@ func (hint Uint256InvModN) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerCo
 	return nil
 }
 
+// Uint256InvModNStrict works the same as Uint256InvModN for callers that require b
+// to be invertible modulo n: instead of writing the g != 1 branch data, it errors
+// when gcd(b, n) != 1
+type Uint256InvModNStrict struct {
+	Uint256InvModN
+}
+
+func (hint Uint256InvModNStrict) String() string {
+	return "Uint256InvModNStrict"
+}
+
+func (hint Uint256InvModNStrict) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	limbs := []hinter.Reference{hint.B0, hint.B1, hint.N0, hint.N1}
+	values := make([]big.Int, len(limbs))
+	for i, limb := range limbs {
+		felt, err := hinter.ResolveAsFelt(vm, limb)
+		if err != nil {
+			return fmt.Errorf("resolve operand %s: %w", limb, err)
+		}
+		felt.BigInt(&values[i])
+	}
+
+	b := new(big.Int).Lsh(&values[1], 128)
+	b.Add(b, &values[0])
+	n := new(big.Int).Lsh(&values[3], 128)
+	n.Add(n, &values[2])
+
+	if n.Cmp(big.NewInt(1)) != 0 {
+		g := new(big.Int).GCD(nil, nil, b, n)
+		if g.Cmp(big.NewInt(1)) != 0 {
+			return fmt.Errorf("%s has no inverse modulo %s: their gcd is %s", b, n, g)
+		}
+	}
+
+	return hint.Uint256InvModN.Execute(vm, ctx)
+}
+
 type Uint256DivMod struct {
 	dividend0  hinter.Reference
 	dividend1  hinter.Reference
@@ -715,7 +832,7 @@ func (hint Uint256DivMod) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerCon
 	if err != nil {
 		return fmt.Errorf("resolve dividend0 operand %s: %v", hint.dividend0, err)
 	}
-	dividend0Felt, err := dividend0.FieldElement()
+	dividend0Felt, err := dividend0.ExpectFelt("dividend0")
 	if err != nil {
 		return err
 	}
@@ -723,7 +840,7 @@ func (hint Uint256DivMod) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerCon
 	if err != nil {
 		return fmt.Errorf("resolve dividend1 operand %s: %v", hint.dividend1, err)
 	}
-	dividend1Felt, err := dividend1.FieldElement()
+	dividend1Felt, err := dividend1.ExpectFelt("dividend1")
 	if err != nil {
 		return err
 	}
@@ -731,7 +848,7 @@ func (hint Uint256DivMod) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerCon
 	if err != nil {
 		return fmt.Errorf("resolve divisor0 operand %s: %v", hint.divisor0, err)
 	}
-	divisor0Felt, err := divisor0.FieldElement()
+	divisor0Felt, err := divisor0.ExpectFelt("divisor0")
 	if err != nil {
 		return err
 	}
@@ -739,7 +856,7 @@ func (hint Uint256DivMod) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerCon
 	if err != nil {
 		return fmt.Errorf("resolve divisor1 operand %s: %v", hint.divisor1, err)
 	}
-	divisor1Felt, err := divisor1.FieldElement()
+	divisor1Felt, err := divisor1.ExpectFelt("divisor1")
 	if err != nil {
 		return err
 	}
@@ -822,6 +939,8 @@ func (hint Uint256DivMod) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerCon
 type DebugPrint struct {
 	start hinter.Reference
 	end   hinter.Reference
+	// Writer receives the debug output, defaults to os.Stdout when nil
+	Writer io.Writer
 }
 
 func (hint DebugPrint) String() string {
@@ -852,6 +971,11 @@ func (hint DebugPrint) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContex
 		return fmt.Errorf("start cannot be greater than end")
 	}
 
+	writer := hint.Writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+
 	current := startAddr.Offset
 	for current < endAddr.Offset {
 		v, err := vm.Memory.ReadFromAddress(&mem.MemoryAddress{
@@ -863,13 +987,90 @@ func (hint DebugPrint) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContex
 		}
 
 		field, _ := v.FieldElement()
-		fmt.Printf("[DEBUG] %s\n", field.Text(16))
+		_, err = fmt.Fprintf(writer, "[DEBUG] %s\n", field.Text(16))
+		if err != nil {
+			return fmt.Errorf("write debug output: %w", err)
+		}
 		current += 1
 	}
 
 	return nil
 }
 
+// Prints each felt of the [start, end) range decoded as a Cairo short string,
+// i.e. up to 31 ASCII bytes packed big-endian into a felt
+type DebugPrintString struct {
+	start hinter.Reference
+	end   hinter.Reference
+	// Writer receives the debug output, defaults to os.Stdout when nil
+	Writer io.Writer
+}
+
+func (hint DebugPrintString) String() string {
+	return "DebugPrintString"
+}
+
+func (hint DebugPrintString) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	startAddr, err := hinter.ResolveAsAddress(vm, hint.start)
+	if err != nil {
+		return fmt.Errorf("resolve start address: %w", err)
+	}
+	endAddr, err := hinter.ResolveAsAddress(vm, hint.end)
+	if err != nil {
+		return fmt.Errorf("resolve end address: %w", err)
+	}
+	if startAddr.SegmentIndex != endAddr.SegmentIndex {
+		return fmt.Errorf("start %s and end %s are in different segments", startAddr, endAddr)
+	}
+	if startAddr.Offset > endAddr.Offset {
+		return fmt.Errorf("start %s is after end %s", startAddr, endAddr)
+	}
+	length := endAddr.Offset - startAddr.Offset
+	if length > hinter.MaxLength {
+		return fmt.Errorf("range of %d felts exceeds the maximum of %d", length, hinter.MaxLength)
+	}
+
+	writer := hint.Writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+
+	values, err := vm.Memory.ResolveAsBigIntN(*startAddr, int(length))
+	if err != nil {
+		return err
+	}
+	for i, value := range values {
+		text, err := decodeShortString(value)
+		if err != nil {
+			return fmt.Errorf("felt %d of the range: %w", i, err)
+		}
+		_, err = fmt.Fprintf(writer, "[DEBUG] %s\n", text)
+		if err != nil {
+			return fmt.Errorf("write debug output: %w", err)
+		}
+	}
+	return nil
+}
+
+// Decodes a felt holding a Cairo short string: its big-endian bytes without the
+// leading zeros, which must all be printable ASCII characters
+func decodeShortString(value *f.Element) (string, error) {
+	bytes := value.Bytes()
+	if bytes[0] != 0 {
+		return "", fmt.Errorf("%s does not fit in a short string of 31 bytes", value)
+	}
+	start := 0
+	for start < len(bytes) && bytes[start] == 0 {
+		start++
+	}
+	for _, b := range bytes[start:] {
+		if b < 0x20 || b > 0x7e {
+			return "", fmt.Errorf("%s contains the non-printable byte 0x%02x", value, b)
+		}
+	}
+	return string(bytes[start:]), nil
+}
+
 type SquareRoot struct {
 	value hinter.Reference
 	dst   hinter.Reference
@@ -910,6 +1111,9 @@ func (hint *SquareRoot) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerConte
 	return nil
 }
 
+// Computes the square root of a u256 value along with its remainder. The
+// full-precision remainder is also kept in scope as "u256_sqrt_remainder" (*big.Int),
+// so follow-up verification hints don't need to recompute it
 type Uint256SquareRoot struct {
 	valueLow                     hinter.Reference
 	valueHigh                    hinter.Reference
@@ -924,7 +1128,7 @@ func (hint Uint256SquareRoot) String() string {
 	return "Uint256SquareRoot"
 }
 
-func (hint Uint256SquareRoot) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+func (hint Uint256SquareRoot) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
 	valueLow, err := hint.valueLow.Resolve(vm)
 	if err != nil {
 		return fmt.Errorf("resolve valueLow operand %s: %v", hint.valueLow, err)
@@ -935,12 +1139,12 @@ func (hint Uint256SquareRoot) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunne
 		return fmt.Errorf("resolve valueHigh operand %s: %v", hint.valueHigh, err)
 	}
 
-	valueLowFelt, err := valueLow.FieldElement()
+	valueLowFelt, err := valueLow.ExpectFelt("valueLow")
 	if err != nil {
 		return err
 	}
 
-	valueHighFelt, err := valueHigh.FieldElement()
+	valueHighFelt, err := valueHigh.ExpectFelt("valueHigh")
 	if err != nil {
 		return err
 	}
@@ -1060,7 +1264,7 @@ func (hint Uint256SquareRoot) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunne
 		return fmt.Errorf("write sqrtMul2MinusRemainderGeU128Addr cell: %v", err)
 	}
 
-	return nil
+	return ctx.ScopeManager.AssignVariable("u256_sqrt_remainder", remainder.ToBig())
 }
 
 //
@@ -1471,7 +1675,7 @@ func (hint Uint512DivModByUint256) Execute(vm *VM.VirtualMachine, _ *hinter.Hint
 	if err != nil {
 		return fmt.Errorf("resolve dividend0 operand %s: %v", hint.dividend0, err)
 	}
-	dividend0Felt, err := dividend0.FieldElement()
+	dividend0Felt, err := dividend0.ExpectFelt("dividend0")
 	if err != nil {
 		return err
 	}
@@ -1479,7 +1683,7 @@ func (hint Uint512DivModByUint256) Execute(vm *VM.VirtualMachine, _ *hinter.Hint
 	if err != nil {
 		return fmt.Errorf("resolve dividend1 operand %s: %v", hint.dividend1, err)
 	}
-	dividend1Felt, err := dividend1.FieldElement()
+	dividend1Felt, err := dividend1.ExpectFelt("dividend1")
 	if err != nil {
 		return err
 	}
@@ -1487,7 +1691,7 @@ func (hint Uint512DivModByUint256) Execute(vm *VM.VirtualMachine, _ *hinter.Hint
 	if err != nil {
 		return fmt.Errorf("resolve dividend2 operand %s: %v", hint.dividend2, err)
 	}
-	dividend2Felt, err := dividend2.FieldElement()
+	dividend2Felt, err := dividend2.ExpectFelt("dividend2")
 	if err != nil {
 		return err
 	}
@@ -1495,7 +1699,7 @@ func (hint Uint512DivModByUint256) Execute(vm *VM.VirtualMachine, _ *hinter.Hint
 	if err != nil {
 		return fmt.Errorf("resolve dividend3 operand %s: %v", hint.dividend3, err)
 	}
-	dividend3Felt, err := dividend3.FieldElement()
+	dividend3Felt, err := dividend3.ExpectFelt("dividend3")
 	if err != nil {
 		return err
 	}
@@ -1504,7 +1708,7 @@ func (hint Uint512DivModByUint256) Execute(vm *VM.VirtualMachine, _ *hinter.Hint
 	if err != nil {
 		return fmt.Errorf("resolve divisor0 operand %s: %v", hint.divisor0, err)
 	}
-	divisor0Felt, err := divisor0.FieldElement()
+	divisor0Felt, err := divisor0.ExpectFelt("divisor0")
 	if err != nil {
 		return err
 	}
@@ -1512,7 +1716,7 @@ func (hint Uint512DivModByUint256) Execute(vm *VM.VirtualMachine, _ *hinter.Hint
 	if err != nil {
 		return fmt.Errorf("resolve divisor1 operand %s: %v", hint.divisor1, err)
 	}
-	divisor1Felt, err := divisor1.FieldElement()
+	divisor1Felt, err := divisor1.ExpectFelt("divisor1")
 	if err != nil {
 		return err
 	}
@@ -1637,6 +1841,8 @@ func (hint *AllocConstantSize) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRu
 
 	if ctx.ConstantSizeSegment.Equal(&mem.UnknownAddress) {
 		ctx.ConstantSizeSegment = vm.Memory.AllocateEmptySegment()
+	} else if err := validateConstantSizeSegment(vm, ctx.ConstantSizeSegment); err != nil {
+		return err
 	}
 
 	dst, err := hint.Dst.Get(vm)
@@ -1654,6 +1860,27 @@ func (hint *AllocConstantSize) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRu
 	return nil
 }
 
+// Checks that a constant size segment recorded on a context still points to a
+// segment of the current memory, and that no cell was written past the offset
+// the next allocation starts from. This catches contexts reused across runs
+func validateConstantSizeSegment(vm *VM.VirtualMachine, segmentAddr mem.MemoryAddress) error {
+	if segmentAddr.SegmentIndex < 0 || segmentAddr.SegmentIndex >= len(vm.Memory.Segments) {
+		return fmt.Errorf("constant size segment %s: segment is not allocated", segmentAddr)
+	}
+
+	segment := vm.Memory.Segments[segmentAddr.SegmentIndex]
+	if _, ok := segment.BuiltinRunner.(*mem.NoBuiltin); !ok {
+		return fmt.Errorf("constant size segment %s: segment belongs to %s", segmentAddr, segment.BuiltinRunner)
+	}
+	if segment.Len() > segmentAddr.Offset {
+		return fmt.Errorf(
+			"constant size segment %s: segment already has %d cells in use",
+			segmentAddr, segment.Len(),
+		)
+	}
+	return nil
+}
+
 type AssertLeFindSmallArc struct {
 	A             hinter.Reference
 	B             hinter.Reference
@@ -1787,7 +2014,7 @@ func (hint *AssertLeIsFirstArcExcluded) Execute(vm *VM.VirtualMachine, ctx *hint
 	}
 
 	var writeValue mem.MemoryValue
-	excluded, err := ctx.ScopeManager.GetVariableValue("excluded")
+	excluded, err := ctx.ScopeManager.GetInt("excluded")
 	if err != nil {
 		return err
 	}
@@ -1815,7 +2042,7 @@ func (hint *AssertLeIsSecondArcExcluded) Execute(vm *VM.VirtualMachine, ctx *hin
 	}
 
 	var writeValue mem.MemoryValue
-	excluded, err := ctx.ScopeManager.GetVariableValue("excluded")
+	excluded, err := ctx.ScopeManager.GetInt("excluded")
 	if err != nil {
 		return err
 	}
@@ -1828,6 +2055,51 @@ func (hint *AssertLeIsSecondArcExcluded) Execute(vm *VM.VirtualMachine, ctx *hin
 	return vm.Memory.WriteToAddress(&addr, &writeValue)
 }
 
+// Checks that the arc stored in the "excluded" scope variable by the 128-bit arc
+// finder is one of the three arcs, numbered as in AssertLeFindSmallArc
+func assertExcludedArc(ctx *hinter.HintRunnerContext) error {
+	excluded, err := ctx.ScopeManager.GetInt("excluded")
+	if err != nil {
+		return err
+	}
+	if excluded < 0 || excluded > 2 {
+		return fmt.Errorf("excluded arc %d is not one of the three arcs", excluded)
+	}
+	return nil
+}
+
+// 128-bit range variant of AssertLeIsFirstArcExcluded
+type AssertLeIsFirstArcExcluded128 struct {
+	SkipExcludeAFlag hinter.Reference
+}
+
+func (hint *AssertLeIsFirstArcExcluded128) String() string {
+	return "AssertLeIsFirstArcExcluded128"
+}
+
+func (hint *AssertLeIsFirstArcExcluded128) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	if err := assertExcludedArc(ctx); err != nil {
+		return err
+	}
+	return (&AssertLeIsFirstArcExcluded{SkipExcludeAFlag: hint.SkipExcludeAFlag}).Execute(vm, ctx)
+}
+
+// 128-bit range variant of AssertLeIsSecondArcExcluded
+type AssertLeIsSecondArcExcluded128 struct {
+	SkipExcludeBMinusA hinter.Reference
+}
+
+func (hint *AssertLeIsSecondArcExcluded128) String() string {
+	return "AssertLeIsSecondArcExcluded128"
+}
+
+func (hint *AssertLeIsSecondArcExcluded128) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	if err := assertExcludedArc(ctx); err != nil {
+		return err
+	}
+	return (&AssertLeIsSecondArcExcluded{SkipExcludeBMinusA: hint.SkipExcludeBMinusA}).Execute(vm, ctx)
+}
+
 type RandomEcPoint struct {
 	x hinter.Reference
 	y hinter.Reference
@@ -1908,30 +2180,64 @@ func (hint *FieldSqrt) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContex
 		return err
 	}
 
-	threeFelt := f.Element{}
-	threeFelt.SetUint64(3)
+	res, _ := sqrtOperand(valFelt)
+	res.Sqrt(&res)
+
+	sqrtVal := mem.MemoryValueFromFieldElement(&res)
+
+	sqrtAddr, err := hint.sqrt.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get sqrt address: %v", err)
+	}
+
+	return vm.Memory.WriteToAddress(&sqrtAddr, &sqrtVal)
+}
 
-	var res f.Element
+// Returns the value a square root can be taken of: the value itself when it is a
+// quadratic residue or zero, and the value multiplied by 3 otherwise. Since 3 is a
+// non-residue in the field, the product of two non-residues is always a residue
+func sqrtOperand(value *f.Element) (f.Element, bool) {
 	// Legendre == 1 -> Quadratic residue
 	// Legendre == -1 -> Quadratic non-residue
 	// Legendre == 0 -> Zero
 	// https://en.wikipedia.org/wiki/Legendre_symbol
-	if valFelt.Legendre() == 1 {
-		res = *valFelt
-	} else {
-		res = *valFelt.Mul(valFelt, &threeFelt)
+	if value.Legendre() == -1 {
+		threeFelt := f.NewElement(3)
+		var operand f.Element
+		operand.Mul(value, &threeFelt)
+		return operand, false
 	}
+	return *value, true
+}
 
-	res.Sqrt(&res)
+type FieldSqrtStrict struct {
+	value hinter.Reference
+	dst   hinter.Reference
+}
 
-	sqrtVal := mem.MemoryValueFromFieldElement(&res)
+func (hint *FieldSqrtStrict) String() string {
+	return "FieldSqrtStrict"
+}
 
-	sqrtAddr, err := hint.sqrt.Get(vm)
+func (hint *FieldSqrtStrict) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
 	if err != nil {
-		return fmt.Errorf("get sqrt address: %v", err)
+		return fmt.Errorf("resolve value operand: %w", err)
 	}
 
-	return vm.Memory.WriteToAddress(&sqrtAddr, &sqrtVal)
+	root, isResidue := sqrtOperand(value)
+	if !isResidue {
+		return fmt.Errorf("%s is not a quadratic residue", value)
+	}
+	root.Sqrt(&root)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	dstVal := mem.MemoryValueFromFieldElement(&root)
+	return vm.Memory.WriteToAddress(&dstAddr, &dstVal)
 }
 
 type ExternalWriteArgsToMemory struct{}
@@ -2024,3 +2330,3022 @@ func (hint *RelocateAllDictionaries) Execute(vm *VM.VirtualMachine, ctx *hinter.
 	ctx.DictionaryManager.RelocateAllDictionaries(vm)
 	return nil
 }
+
+type ConditionalSplit struct {
+	value   hinter.Reference
+	cond    hinter.Reference
+	lowDst  hinter.Reference
+	highDst hinter.Reference
+}
+
+func (hint *ConditionalSplit) String() string {
+	return "ConditionalSplit"
+}
+
+func (hint *ConditionalSplit) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand: %w", err)
+	}
+
+	cond, err := hinter.ResolveAsFelt(vm, hint.cond)
+	if err != nil {
+		return fmt.Errorf("resolve cond operand: %w", err)
+	}
+
+	// When cond is zero the whole value goes to low and high is set to zero,
+	// otherwise value is split into its 128-bit low and high parts
+	low := *value
+	high := f.Element{}
+	if !cond.IsZero() {
+		valueBytes := value.Bytes()
+		low.SetBytes(valueBytes[16:])
+		high.SetBytes(valueBytes[:16])
+	}
+
+	lowAddr, err := hint.lowDst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get low destination cell: %w", err)
+	}
+	lowVal := mem.MemoryValueFromFieldElement(&low)
+	if err = vm.Memory.WriteToAddress(&lowAddr, &lowVal); err != nil {
+		return fmt.Errorf("write low cell: %w", err)
+	}
+
+	highAddr, err := hint.highDst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get high destination cell: %w", err)
+	}
+	highVal := mem.MemoryValueFromFieldElement(&high)
+	if err = vm.Memory.WriteToAddress(&highAddr, &highVal); err != nil {
+		return fmt.Errorf("write high cell: %w", err)
+	}
+
+	return nil
+}
+
+type HammingDistance struct {
+	a   hinter.Reference
+	b   hinter.Reference
+	dst hinter.Reference
+}
+
+func (hint *HammingDistance) String() string {
+	return "HammingDistance"
+}
+
+func (hint *HammingDistance) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	a, err := hinter.ResolveAsFelt(vm, hint.a)
+	if err != nil {
+		return fmt.Errorf("resolve a operand: %w", err)
+	}
+
+	b, err := hinter.ResolveAsFelt(vm, hint.b)
+	if err != nil {
+		return fmt.Errorf("resolve b operand: %w", err)
+	}
+
+	// distance = popcount(a ^ b)
+	aU256 := uint256.Int(a.Bits())
+	bU256 := uint256.Int(b.Bits())
+	xor := uint256.Int{}
+	xor.Xor(&aU256, &bU256)
+
+	distance := 0
+	for _, limb := range xor {
+		distance += bits.OnesCount64(limb)
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	dstVal := mem.MemoryValueFromInt(distance)
+	return vm.Memory.WriteToAddress(&dstAddr, &dstVal)
+}
+
+type Bitwise struct {
+	lhs hinter.Reference
+	rhs hinter.Reference
+	and hinter.Reference
+	or  hinter.Reference
+	xor hinter.Reference
+}
+
+func (hint *Bitwise) String() string {
+	return "Bitwise"
+}
+
+func (hint *Bitwise) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	// Same bit width the bitwise builtin operates on
+	const bitwiseTotalNBits = 251
+
+	lhs, err := hint.lhs.Resolve(vm)
+	if err != nil {
+		return fmt.Errorf("resolve lhs operand %s: %w", hint.lhs, err)
+	}
+	rhs, err := hint.rhs.Resolve(vm)
+	if err != nil {
+		return fmt.Errorf("resolve rhs operand %s: %w", hint.rhs, err)
+	}
+
+	lhsFelt, err := lhs.FieldElement()
+	if err != nil {
+		return err
+	}
+	rhsFelt, err := rhs.FieldElement()
+	if err != nil {
+		return err
+	}
+
+	// Bits returns the canonical limbs, unlike BitLen which works on the Montgomery form
+	lhsU256 := uint256.Int(lhsFelt.Bits())
+	rhsU256 := uint256.Int(rhsFelt.Bits())
+	if lhsU256.BitLen() > bitwiseTotalNBits {
+		return fmt.Errorf("lhs operand %s should fit in %d bits", lhsFelt, bitwiseTotalNBits)
+	}
+	if rhsU256.BitLen() > bitwiseTotalNBits {
+		return fmt.Errorf("rhs operand %s should fit in %d bits", rhsFelt, bitwiseTotalNBits)
+	}
+
+	and := uint256.Int{}
+	and.And(&lhsU256, &rhsU256)
+	or := uint256.Int{}
+	or.Or(&lhsU256, &rhsU256)
+	xor := uint256.Int{}
+	xor.Xor(&lhsU256, &rhsU256)
+
+	results := []struct {
+		name  string
+		dst   hinter.Reference
+		value *uint256.Int
+	}{
+		{"and", hint.and, &and},
+		{"or", hint.or, &or},
+		{"xor", hint.xor, &xor},
+	}
+	for _, res := range results {
+		resFelt := f.Element{}
+		resFelt.SetBytes(res.value.Bytes())
+
+		resAddr, err := res.dst.Get(vm)
+		if err != nil {
+			return fmt.Errorf("get %s destination cell: %w", res.name, err)
+		}
+		resVal := mem.MemoryValueFromFieldElement(&resFelt)
+		if err = vm.Memory.WriteToAddress(&resAddr, &resVal); err != nil {
+			return fmt.Errorf("write %s cell: %w", res.name, err)
+		}
+	}
+	return nil
+}
+
+type ExtendedGcdInv struct {
+	a   hinter.Reference
+	m   hinter.Reference
+	dst hinter.Reference
+}
+
+func (hint *ExtendedGcdInv) String() string {
+	return "ExtendedGcdInv"
+}
+
+func (hint *ExtendedGcdInv) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	aFelt, err := hinter.ResolveAsFelt(vm, hint.a)
+	if err != nil {
+		return fmt.Errorf("resolve a operand: %w", err)
+	}
+
+	mFelt, err := hinter.ResolveAsFelt(vm, hint.m)
+	if err != nil {
+		return fmt.Errorf("resolve m operand: %w", err)
+	}
+
+	var a, m big.Int
+	aFelt.BigInt(&a)
+	mFelt.BigInt(&m)
+
+	if m.Sign() == 0 {
+		return fmt.Errorf("modulus cannot be zero")
+	}
+
+	// x * a + y * m = gcd(a, m)
+	x, _, g := utils.Igcdex(&a, &m)
+	if g.Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("no inverse exists: gcd(%s, %s) = %s", &a, &m, &g)
+	}
+	x.Mod(&x, &m)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	dstVal := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(&x))
+	return vm.Memory.WriteToAddress(&dstAddr, &dstVal)
+}
+
+type AssembleUInt384 struct {
+	limbsPtr hinter.Reference
+}
+
+func (hint *AssembleUInt384) String() string {
+	return "AssembleUInt384"
+}
+
+func (hint *AssembleUInt384) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	const limbBitLen = 96
+
+	limbsPtr, err := hinter.ResolveAsAddress(vm, hint.limbsPtr)
+	if err != nil {
+		return fmt.Errorf("resolve limbs pointer: %w", err)
+	}
+
+	limbs, err := vm.Memory.GetConsecutiveMemoryValues(*limbsPtr, builtins.N_WORDS)
+	if err != nil {
+		return fmt.Errorf("read limbs: %w", err)
+	}
+
+	// value = d0 + d1 * 2**96 + d2 * 2**192 + d3 * 2**288
+	value := new(big.Int)
+	for i := len(limbs) - 1; i >= 0; i-- {
+		limb, err := limbs[i].FieldElement()
+		if err != nil {
+			return fmt.Errorf("limb %d: %w", i, err)
+		}
+		var limbBig big.Int
+		limb.BigInt(&limbBig)
+		if limbBig.BitLen() > limbBitLen {
+			return fmt.Errorf("limb %d: %s should fit in %d bits", i, limb, limbBitLen)
+		}
+
+		value.Lsh(value, limbBitLen)
+		value.Add(value, &limbBig)
+	}
+
+	return ctx.ScopeManager.AssignVariable("value", value)
+}
+
+type SplitUInt384 struct {
+	// When value is nil, the big.Int stored in scope under `value` is split instead
+	value  hinter.Reference
+	dstPtr hinter.Reference
+}
+
+func (hint *SplitUInt384) String() string {
+	return "SplitUInt384"
+}
+
+func (hint *SplitUInt384) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	const limbBitLen = 96
+
+	value := new(big.Int)
+	if hint.value != nil {
+		valueFelt, err := hinter.ResolveAsFelt(vm, hint.value)
+		if err != nil {
+			return fmt.Errorf("resolve value operand: %w", err)
+		}
+		valueFelt.BigInt(value)
+	} else {
+		scopeValue, err := hinter.GetVariableAs[*big.Int](&ctx.ScopeManager, "value")
+		if err != nil {
+			return fmt.Errorf("get value: %w", err)
+		}
+		value.Set(scopeValue)
+	}
+
+	if value.Sign() < 0 || value.BitLen() > builtins.N_WORDS*limbBitLen {
+		return fmt.Errorf("value %s should fit in %d bits", value, builtins.N_WORDS*limbBitLen)
+	}
+
+	dstPtr, err := hinter.ResolveAsAddress(vm, hint.dstPtr)
+	if err != nil {
+		return fmt.Errorf("resolve destination pointer: %w", err)
+	}
+
+	mask := new(big.Int).Lsh(big.NewInt(1), limbBitLen)
+	mask.Sub(mask, big.NewInt(1))
+
+	for i := 0; i < builtins.N_WORDS; i++ {
+		limb := new(big.Int).And(value, mask)
+		value.Rsh(value, limbBitLen)
+
+		limbVal := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(limb))
+		if err := vm.Memory.WriteToNthStructField(*dstPtr, limbVal, int16(i)); err != nil {
+			return fmt.Errorf("write limb %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+type KeccakF1600 struct {
+	inputPtr  hinter.Reference
+	outputPtr hinter.Reference
+}
+
+func (hint *KeccakF1600) String() string {
+	return "KeccakF1600"
+}
+
+func (hint *KeccakF1600) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	const keccakStateSize = 25
+
+	inputPtr, err := hinter.ResolveAsAddress(vm, hint.inputPtr)
+	if err != nil {
+		return fmt.Errorf("resolve input pointer: %w", err)
+	}
+
+	outputPtr, err := hinter.ResolveAsAddress(vm, hint.outputPtr)
+	if err != nil {
+		return fmt.Errorf("resolve output pointer: %w", err)
+	}
+
+	inputValues, err := vm.Memory.GetConsecutiveMemoryValues(*inputPtr, keccakStateSize)
+	if err != nil {
+		return fmt.Errorf("read input lanes: %w", err)
+	}
+
+	var state [keccakStateSize]uint64
+	for i := range inputValues {
+		lane, err := inputValues[i].Uint64()
+		if err != nil {
+			return fmt.Errorf("lane %d should be u64: %w", i, err)
+		}
+		state[i] = lane
+	}
+
+	builtins.KeccakF1600(&state)
+
+	for i := range state {
+		laneVal := mem.MemoryValueFromUint(state[i])
+		if err := vm.Memory.WriteToNthStructField(*outputPtr, laneVal, int16(i)); err != nil {
+			return fmt.Errorf("write output lane %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+type AddMod struct {
+	a       hinter.Reference
+	b       hinter.Reference
+	modulus hinter.Reference
+	dst     hinter.Reference
+}
+
+func (hint *AddMod) String() string {
+	return "AddMod"
+}
+
+func (hint *AddMod) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	aFelt, err := hinter.ResolveAsFelt(vm, hint.a)
+	if err != nil {
+		return fmt.Errorf("resolve a operand: %w", err)
+	}
+
+	bFelt, err := hinter.ResolveAsFelt(vm, hint.b)
+	if err != nil {
+		return fmt.Errorf("resolve b operand: %w", err)
+	}
+
+	modulusFelt, err := hinter.ResolveAsFelt(vm, hint.modulus)
+	if err != nil {
+		return fmt.Errorf("resolve modulus operand: %w", err)
+	}
+	if modulusFelt.IsZero() {
+		return fmt.Errorf("modulus is zero")
+	}
+
+	var a, b, modulus big.Int
+	aFelt.BigInt(&a)
+	bFelt.BigInt(&b)
+	modulusFelt.BigInt(&modulus)
+
+	// res = (a + b) % modulus
+	res := new(big.Int).Add(&a, &b)
+	res.Mod(res, &modulus)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	dstVal := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(res))
+	return vm.Memory.WriteToAddress(&dstAddr, &dstVal)
+}
+
+type MulMod struct {
+	a       hinter.Reference
+	b       hinter.Reference
+	modulus hinter.Reference
+	dst     hinter.Reference
+}
+
+func (hint *MulMod) String() string {
+	return "MulMod"
+}
+
+func (hint *MulMod) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	aFelt, err := hinter.ResolveAsFelt(vm, hint.a)
+	if err != nil {
+		return fmt.Errorf("resolve a operand: %w", err)
+	}
+
+	bFelt, err := hinter.ResolveAsFelt(vm, hint.b)
+	if err != nil {
+		return fmt.Errorf("resolve b operand: %w", err)
+	}
+
+	modulusFelt, err := hinter.ResolveAsFelt(vm, hint.modulus)
+	if err != nil {
+		return fmt.Errorf("resolve modulus operand: %w", err)
+	}
+	if modulusFelt.IsZero() {
+		return fmt.Errorf("modulus is zero")
+	}
+
+	var a, b, modulus big.Int
+	aFelt.BigInt(&a)
+	bFelt.BigInt(&b)
+	modulusFelt.BigInt(&modulus)
+
+	// res = (a * b) % modulus
+	res := new(big.Int).Mul(&a, &b)
+	res.Mod(res, &modulus)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	dstVal := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(res))
+	return vm.Memory.WriteToAddress(&dstAddr, &dstVal)
+}
+
+type SignedDivMod struct {
+	lhs       hinter.Reference
+	rhs       hinter.Reference
+	quotient  hinter.Reference
+	remainder hinter.Reference
+}
+
+func (hint *SignedDivMod) String() string {
+	return "SignedDivMod"
+}
+
+func (hint *SignedDivMod) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	lhsFelt, err := hinter.ResolveAsFelt(vm, hint.lhs)
+	if err != nil {
+		return fmt.Errorf("resolve lhs operand: %w", err)
+	}
+
+	rhsFelt, err := hinter.ResolveAsFelt(vm, hint.rhs)
+	if err != nil {
+		return fmt.Errorf("resolve rhs operand: %w", err)
+	}
+	if rhsFelt.IsZero() {
+		return fmt.Errorf("cannot be divided by zero, rhs: %v", rhsFelt)
+	}
+
+	// Felts above the field midpoint are interpreted as negative values
+	lhs := u.AsInt(lhsFelt)
+	rhs := u.AsInt(rhsFelt)
+
+	// Floor division: the remainder takes the sign of the divisor
+	quotient, remainder := new(big.Int).QuoRem(&lhs, &rhs, new(big.Int))
+	if remainder.Sign() != 0 && remainder.Sign() != rhs.Sign() {
+		quotient.Sub(quotient, big.NewInt(1))
+		remainder.Add(remainder, &rhs)
+	}
+
+	quotientAddr, err := hint.quotient.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get quotient cell: %w", err)
+	}
+	quotientVal := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(quotient))
+	if err = vm.Memory.WriteToAddress(&quotientAddr, &quotientVal); err != nil {
+		return fmt.Errorf("write quotient cell: %w", err)
+	}
+
+	remainderAddr, err := hint.remainder.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get remainder cell: %w", err)
+	}
+	remainderVal := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(remainder))
+	if err = vm.Memory.WriteToAddress(&remainderAddr, &remainderVal); err != nil {
+		return fmt.Errorf("write remainder cell: %w", err)
+	}
+
+	return nil
+}
+
+type ComputeBarrettMu struct {
+	modulus hinter.Reference
+	dst     hinter.Reference
+}
+
+func (hint *ComputeBarrettMu) String() string {
+	return "ComputeBarrettMu"
+}
+
+func (hint *ComputeBarrettMu) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	// Largest shift for which mu is guaranteed to fit in a felt for any non-zero modulus
+	const barrettShift = 251
+
+	modulusFelt, err := hinter.ResolveAsFelt(vm, hint.modulus)
+	if err != nil {
+		return fmt.Errorf("resolve modulus operand: %w", err)
+	}
+	if modulusFelt.IsZero() {
+		return fmt.Errorf("modulus is zero")
+	}
+
+	var modulus big.Int
+	modulusFelt.BigInt(&modulus)
+
+	// mu = 2**k // modulus
+	mu := new(big.Int).Lsh(big.NewInt(1), barrettShift)
+	mu.Div(mu, &modulus)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	dstVal := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(mu))
+	return vm.Memory.WriteToAddress(&dstAddr, &dstVal)
+}
+
+type ValidateDictChain struct {
+	accessPtr hinter.Reference
+	n         hinter.Reference
+}
+
+func (hint *ValidateDictChain) String() string {
+	return "ValidateDictChain"
+}
+
+func (hint *ValidateDictChain) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	accessPtr, err := hinter.ResolveAsAddress(vm, hint.accessPtr)
+	if err != nil {
+		return fmt.Errorf("resolve access pointer: %w", err)
+	}
+
+	n, err := hinter.ResolveAsLength(vm, hint.n)
+	if err != nil {
+		return fmt.Errorf("resolve number of accesses: %w", err)
+	}
+
+	// The access log must belong to a dictionary tracked by the manager
+	if _, err := ctx.DictionaryManager.GetDictionary(accessPtr); err != nil {
+		return err
+	}
+
+	const dictAccessSize = 3
+	accesses, err := vm.Memory.GetConsecutiveMemoryValues(*accessPtr, n*dictAccessSize)
+	if err != nil {
+		return fmt.Errorf("read accesses: %w", err)
+	}
+
+	// For each key, the latest `new` value seen in the chain
+	lastNew := make(map[f.Element]mem.MemoryValue)
+	for i := uint64(0); i < n; i++ {
+		key, err := accesses[i*dictAccessSize].FieldElement()
+		if err != nil {
+			return fmt.Errorf("access %d key: %w", i, err)
+		}
+		prevValue := accesses[i*dictAccessSize+1]
+		newValue := accesses[i*dictAccessSize+2]
+
+		if expected, ok := lastNew[*key]; ok && !expected.Equal(&prevValue) {
+			return fmt.Errorf(
+				"access %d for key %s: prev value %s does not match previous new value %s",
+				i, key, prevValue, expected,
+			)
+		}
+		lastNew[*key] = newValue
+	}
+
+	return nil
+}
+
+type XorAccumulate struct {
+	ptr hinter.Reference
+	len hinter.Reference
+	dst hinter.Reference
+}
+
+func (hint *XorAccumulate) String() string {
+	return "XorAccumulate"
+}
+
+func (hint *XorAccumulate) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	// Same bit width the bitwise builtin operates on
+	const bitwiseTotalNBits = 251
+
+	ptr, err := hinter.ResolveAsAddress(vm, hint.ptr)
+	if err != nil {
+		return fmt.Errorf("resolve pointer: %w", err)
+	}
+
+	length, err := hinter.ResolveAsLength(vm, hint.len)
+	if err != nil {
+		return fmt.Errorf("resolve length: %w", err)
+	}
+
+	values, err := vm.Memory.ResolveAsBigIntN(*ptr, int(length))
+	if err != nil {
+		return fmt.Errorf("read values: %w", err)
+	}
+
+	// The XOR of an empty range is 0
+	acc := uint256.Int{}
+	for i, value := range values {
+		valueU256 := uint256.Int(value.Bits())
+		if valueU256.BitLen() > bitwiseTotalNBits {
+			return fmt.Errorf("value %d: %s should fit in %d bits", i, value, bitwiseTotalNBits)
+		}
+		acc.Xor(&acc, &valueU256)
+	}
+
+	accFelt := f.Element{}
+	accFelt.SetBytes(acc.Bytes())
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	dstVal := mem.MemoryValueFromFieldElement(&accFelt)
+	return vm.Memory.WriteToAddress(&dstAddr, &dstVal)
+}
+
+type ModInverse struct {
+	value         hinter.Reference
+	modulus       hinter.Reference
+	dst           hinter.Reference
+	notInvertible hinter.Reference
+}
+
+func (hint *ModInverse) String() string {
+	return "ModInverse"
+}
+
+func (hint *ModInverse) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	valueFelt, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand: %w", err)
+	}
+
+	modulusFelt, err := hinter.ResolveAsFelt(vm, hint.modulus)
+	if err != nil {
+		return fmt.Errorf("resolve modulus operand: %w", err)
+	}
+
+	var value, modulus big.Int
+	valueFelt.BigInt(&value)
+	modulusFelt.BigInt(&modulus)
+
+	if modulus.Sign() == 0 {
+		return fmt.Errorf("modulus is zero")
+	}
+
+	// x * value + y * modulus = gcd(value, modulus)
+	inverse, _, g := utils.Igcdex(&value, &modulus)
+
+	// When there is no inverse, the flag is set and 0 is written as the result
+	flag := mem.MemoryValueFromInt(0)
+	if g.Cmp(big.NewInt(1)) != 0 {
+		flag = mem.MemoryValueFromInt(1)
+		inverse.SetUint64(0)
+	}
+	inverse.Mod(&inverse, &modulus)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	dstVal := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(&inverse))
+	if err := vm.Memory.WriteToAddress(&dstAddr, &dstVal); err != nil {
+		return fmt.Errorf("write destination cell: %w", err)
+	}
+
+	flagAddr, err := hint.notInvertible.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get not invertible cell: %w", err)
+	}
+	return vm.Memory.WriteToAddress(&flagAddr, &flag)
+}
+
+type Index2D struct {
+	base   hinter.Reference
+	row    hinter.Reference
+	col    hinter.Reference
+	stride hinter.Reference
+	dst    hinter.Reference
+}
+
+func (hint *Index2D) String() string {
+	return "Index2D"
+}
+
+func (hint *Index2D) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	base, err := hinter.ResolveAsAddress(vm, hint.base)
+	if err != nil {
+		return fmt.Errorf("resolve base pointer: %w", err)
+	}
+
+	row, err := hinter.ResolveAsUint64(vm, hint.row)
+	if err != nil {
+		return fmt.Errorf("resolve row operand: %w", err)
+	}
+	col, err := hinter.ResolveAsUint64(vm, hint.col)
+	if err != nil {
+		return fmt.Errorf("resolve col operand: %w", err)
+	}
+	stride, err := hinter.ResolveAsUint64(vm, hint.stride)
+	if err != nil {
+		return fmt.Errorf("resolve stride operand: %w", err)
+	}
+
+	// offset = base.offset + row * stride + col
+	hi, offset := bits.Mul64(row, stride)
+	if hi != 0 {
+		return fmt.Errorf("offset overflow: %d * %d", row, stride)
+	}
+	offset, carry := bits.Add64(offset, col, 0)
+	if carry != 0 {
+		return fmt.Errorf("offset overflow: %d * %d + %d", row, stride, col)
+	}
+	offset, carry = bits.Add64(base.Offset, offset, 0)
+	if carry != 0 {
+		return fmt.Errorf("offset overflow: %s + %d * %d + %d", base, row, stride, col)
+	}
+
+	elemAddr := mem.MemoryAddress{SegmentIndex: base.SegmentIndex, Offset: offset}
+	elem, err := vm.Memory.ReadFromAddress(&elemAddr)
+	if err != nil {
+		return fmt.Errorf("read element at %s: %w", elemAddr, err)
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	return vm.Memory.WriteToAddress(&dstAddr, &elem)
+}
+
+type FieldInvArray struct {
+	srcPtr hinter.Reference
+	len    hinter.Reference
+	dstPtr hinter.Reference
+}
+
+func (hint *FieldInvArray) String() string {
+	return "FieldInvArray"
+}
+
+func (hint *FieldInvArray) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	srcPtr, err := hinter.ResolveAsAddress(vm, hint.srcPtr)
+	if err != nil {
+		return fmt.Errorf("resolve source pointer: %w", err)
+	}
+
+	length, err := hinter.ResolveAsLength(vm, hint.len)
+	if err != nil {
+		return fmt.Errorf("resolve length: %w", err)
+	}
+
+	dstPtr, err := hinter.ResolveAsAddress(vm, hint.dstPtr)
+	if err != nil {
+		return fmt.Errorf("resolve destination pointer: %w", err)
+	}
+
+	values, err := vm.Memory.ResolveAsBigIntN(*srcPtr, int(length))
+	if err != nil {
+		return fmt.Errorf("read values: %w", err)
+	}
+
+	inverses, err := batchInvert(values)
+	if err != nil {
+		return err
+	}
+
+	inverseValues := make([]mem.MemoryValue, length)
+	for i := range inverses {
+		inverseValues[i] = mem.MemoryValueFromFieldElement(&inverses[i])
+	}
+	return vm.Memory.WriteConsecutiveValues(*dstPtr, inverseValues)
+}
+
+// batchInvert returns the inverses of values, erroring on the first zero element
+func batchInvert(values []*f.Element) ([]f.Element, error) {
+	elements := make([]f.Element, len(values))
+	for i, value := range values {
+		if value.IsZero() {
+			return nil, fmt.Errorf("element %d is zero and has no inverse", i)
+		}
+		elements[i] = *value
+	}
+
+	// Montgomery batch inversion: a single field inversion for the whole array
+	return f.BatchInvert(elements), nil
+}
+
+type SignOf struct {
+	value hinter.Reference
+	dst   hinter.Reference
+}
+
+func (hint *SignOf) String() string {
+	return "SignOf"
+}
+
+func (hint *SignOf) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand: %w", err)
+	}
+
+	// sign is computed over the balanced representation (-p/2, p/2), with -1 written as p - 1
+	signedValue := u.AsInt(value)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	dstVal := mem.MemoryValueFromInt(signedValue.Sign())
+	return vm.Memory.WriteToAddress(&dstAddr, &dstVal)
+}
+
+type IsU64 struct {
+	value hinter.Reference
+	dst   hinter.Reference
+}
+
+func (hint *IsU64) String() string {
+	return "IsU64"
+}
+
+func (hint *IsU64) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand: %w", err)
+	}
+
+	// Unlike the other u64 checks, this one never fails on a value out of range
+	// so that Cairo code can branch on the result
+	fitsU64 := mem.MemoryValueFromInt(0)
+	if value.IsUint64() {
+		fitsU64 = mem.MemoryValueFromInt(1)
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	return vm.Memory.WriteToAddress(&dstAddr, &fitsU64)
+}
+
+type SqrtOrZero struct {
+	value     hinter.Reference
+	dst       hinter.Reference
+	isResidue hinter.Reference
+}
+
+func (hint *SqrtOrZero) String() string {
+	return "SqrtOrZero"
+}
+
+func (hint *SqrtOrZero) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand: %w", err)
+	}
+
+	// Non-residues have no square root: 0 is written and the flag is unset
+	var root f.Element
+	isResidue := mem.MemoryValueFromInt(0)
+	if value.Legendre() != -1 {
+		root.Sqrt(value)
+		isResidue = mem.MemoryValueFromInt(1)
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	rootVal := mem.MemoryValueFromFieldElement(&root)
+	if err := vm.Memory.WriteToAddress(&dstAddr, &rootVal); err != nil {
+		return fmt.Errorf("write destination cell: %w", err)
+	}
+
+	isResidueAddr, err := hint.isResidue.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get is residue cell: %w", err)
+	}
+	return vm.Memory.WriteToAddress(&isResidueAddr, &isResidue)
+}
+
+type PowModSecp struct {
+	base hinter.Reference
+	exp  hinter.Reference
+}
+
+func (hint *PowModSecp) String() string {
+	return "PowModSecp"
+}
+
+func (hint *PowModSecp) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	baseAddr, err := hint.base.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get base address: %w", err)
+	}
+
+	baseLimbs, err := vm.Memory.ResolveAsBigInt3(baseAddr)
+	if err != nil {
+		return fmt.Errorf("resolve base: %w", err)
+	}
+
+	expFelt, err := hinter.ResolveAsFelt(vm, hint.exp)
+	if err != nil {
+		return fmt.Errorf("resolve exp operand: %w", err)
+	}
+
+	secPBig, ok := u.GetSecPBig()
+	if !ok {
+		return fmt.Errorf("GetSecPBig failed")
+	}
+
+	base, err := u.SecPPacked(baseLimbs)
+	if err != nil {
+		return err
+	}
+
+	var exp big.Int
+	expFelt.BigInt(&exp)
+
+	// value = pow(base, exp, SECP_P)
+	value := new(big.Int).Exp(&base, &exp, &secPBig)
+	return ctx.ScopeManager.AssignVariable("value", value)
+}
+
+// Fields of the ModBuiltin struct holding the inputs of a mod builtin instance
+type modBuiltinStruct struct {
+	p          *big.Int
+	valuesPtr  mem.MemoryAddress
+	offsetsPtr mem.MemoryAddress
+	n          uint64
+}
+
+// Reads the ModBuiltin struct at ptr, checking that p and n are felts and that
+// values_ptr and offsets_ptr are addresses
+func readModBuiltinStruct(vm *VM.VirtualMachine, ptr mem.MemoryAddress) (modBuiltinStruct, error) {
+	const wordBitLen = 96
+
+	//> struct ModBuiltin {
+	//>     p: UInt384,
+	//>     values_ptr: UInt384*,
+	//>     offsets_ptr: felt*,
+	//>     n: felt,
+	//> }
+	fields, err := vm.Memory.GetConsecutiveMemoryValues(ptr, builtins.CELLS_PER_MOD)
+	if err != nil {
+		return modBuiltinStruct{}, fmt.Errorf("read ModBuiltin struct: %w", err)
+	}
+
+	// p = p0 + p1 * 2**96 + p2 * 2**192 + p3 * 2**288
+	p := new(big.Int)
+	for i := builtins.N_WORDS - 1; i >= 0; i-- {
+		word, err := fields[i].ExpectFelt(fmt.Sprintf("p%d", i))
+		if err != nil {
+			return modBuiltinStruct{}, err
+		}
+
+		var wordBig big.Int
+		word.BigInt(&wordBig)
+		p.Lsh(p, wordBitLen)
+		p.Add(p, &wordBig)
+	}
+
+	valuesPtr, err := fields[builtins.VALUES_PTR_OFFSET].ExpectAddress("values_ptr")
+	if err != nil {
+		return modBuiltinStruct{}, err
+	}
+	offsetsPtr, err := fields[builtins.OFFSETS_PTR_OFFSET].ExpectAddress("offsets_ptr")
+	if err != nil {
+		return modBuiltinStruct{}, err
+	}
+	nFelt, err := fields[builtins.N_OFFSET].ExpectFelt("n")
+	if err != nil {
+		return modBuiltinStruct{}, err
+	}
+	if !nFelt.IsUint64() {
+		return modBuiltinStruct{}, fmt.Errorf("n: %s should be u64", nFelt)
+	}
+
+	return modBuiltinStruct{
+		p:          p,
+		valuesPtr:  *valuesPtr,
+		offsetsPtr: *offsetsPtr,
+		n:          nFelt.Uint64(),
+	}, nil
+}
+
+type ReadModBuiltinStruct struct {
+	ptr hinter.Reference
+}
+
+func (hint *ReadModBuiltinStruct) String() string {
+	return "ReadModBuiltinStruct"
+}
+
+func (hint *ReadModBuiltinStruct) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	ptr, err := hinter.ResolveAsAddress(vm, hint.ptr)
+	if err != nil {
+		return fmt.Errorf("resolve ModBuiltin pointer: %w", err)
+	}
+
+	modBuiltin, err := readModBuiltinStruct(vm, *ptr)
+	if err != nil {
+		return err
+	}
+
+	return ctx.ScopeManager.AssignVariables(map[string]any{
+		"p":           modBuiltin.p,
+		"values_ptr":  modBuiltin.valuesPtr,
+		"offsets_ptr": modBuiltin.offsetsPtr,
+		"n":           modBuiltin.n,
+	})
+}
+
+type InversePermutation struct {
+	srcPtr hinter.Reference
+	len    hinter.Reference
+	dstPtr hinter.Reference
+}
+
+func (hint *InversePermutation) String() string {
+	return "InversePermutation"
+}
+
+func (hint *InversePermutation) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	srcPtr, err := hinter.ResolveAsAddress(vm, hint.srcPtr)
+	if err != nil {
+		return fmt.Errorf("resolve source pointer: %w", err)
+	}
+
+	length, err := hinter.ResolveAsLength(vm, hint.len)
+	if err != nil {
+		return fmt.Errorf("resolve length: %w", err)
+	}
+
+	dstPtr, err := hinter.ResolveAsAddress(vm, hint.dstPtr)
+	if err != nil {
+		return fmt.Errorf("resolve destination pointer: %w", err)
+	}
+
+	permutation, err := vm.Memory.GetConsecutiveMemoryValues(*srcPtr, length)
+	if err != nil {
+		return fmt.Errorf("read permutation: %w", err)
+	}
+
+	// inverse[permutation[i]] = i
+	inverse := make([]mem.MemoryValue, length)
+	for i := range permutation {
+		index, err := permutation[i].Uint64()
+		if err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+		if index >= length {
+			return fmt.Errorf("element %d: %d is out of range [0, %d)", i, index, length)
+		}
+		if inverse[index].Known() {
+			return fmt.Errorf("element %d: %d appears more than once", i, index)
+		}
+		inverse[index] = mem.MemoryValueFromInt(i)
+	}
+
+	return vm.Memory.WriteConsecutiveValues(*dstPtr, inverse)
+}
+
+type DivModAssert struct {
+	lhs       hinter.Reference
+	rhs       hinter.Reference
+	quotient  hinter.Reference
+	remainder hinter.Reference
+}
+
+func (hint *DivModAssert) String() string {
+	return "DivModAssert"
+}
+
+func (hint *DivModAssert) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	operands := []struct {
+		name string
+		ref  hinter.Reference
+		dst  *big.Int
+	}{
+		{"lhs", hint.lhs, new(big.Int)},
+		{"rhs", hint.rhs, new(big.Int)},
+		{"quotient", hint.quotient, new(big.Int)},
+		{"remainder", hint.remainder, new(big.Int)},
+	}
+	for _, operand := range operands {
+		felt, err := hinter.ResolveAsFelt(vm, operand.ref)
+		if err != nil {
+			return fmt.Errorf("resolve %s operand: %w", operand.name, err)
+		}
+		felt.BigInt(operand.dst)
+	}
+	lhs, rhs, quotient, remainder := operands[0].dst, operands[1].dst, operands[2].dst, operands[3].dst
+
+	// 0 <= remainder < rhs
+	if remainder.Cmp(rhs) >= 0 {
+		return fmt.Errorf("remainder %s should be smaller than rhs %s", remainder, rhs)
+	}
+
+	// lhs == quotient * rhs + remainder, over the integers
+	expected := new(big.Int).Mul(quotient, rhs)
+	expected.Add(expected, remainder)
+	if expected.Cmp(lhs) != 0 {
+		return fmt.Errorf("%s != %s * %s + %s", lhs, quotient, rhs, remainder)
+	}
+	return nil
+}
+
+// Largest scale accepted by FixedPointFromRatio, the bit length of the field prime minus one
+const maxFixedPointScale = 251
+
+type FixedPointFromRatio struct {
+	num   hinter.Reference
+	den   hinter.Reference
+	scale hinter.Reference
+	dst   hinter.Reference
+}
+
+func (hint *FixedPointFromRatio) String() string {
+	return "FixedPointFromRatio"
+}
+
+func (hint *FixedPointFromRatio) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	numFelt, err := hinter.ResolveAsFelt(vm, hint.num)
+	if err != nil {
+		return fmt.Errorf("resolve num operand: %w", err)
+	}
+	denFelt, err := hinter.ResolveAsFelt(vm, hint.den)
+	if err != nil {
+		return fmt.Errorf("resolve den operand: %w", err)
+	}
+	scale, err := hinter.ResolveAsUint64(vm, hint.scale)
+	if err != nil {
+		return fmt.Errorf("resolve scale operand: %w", err)
+	}
+
+	// a larger scale only overflows the field, and shifting by it could exhaust memory
+	if scale > maxFixedPointScale {
+		return fmt.Errorf("scale %d exceeds the maximum of %d", scale, maxFixedPointScale)
+	}
+
+	if denFelt.IsZero() {
+		return fmt.Errorf("cannot be divided by zero, den: %v", denFelt)
+	}
+
+	var num, den big.Int
+	numFelt.BigInt(&num)
+	denFelt.BigInt(&den)
+
+	// value = num * 2**scale // den
+	value := new(big.Int).Lsh(&num, uint(scale))
+	value.Div(value, &den)
+	if value.Cmp(f.Modulus()) >= 0 {
+		return fmt.Errorf("%s * 2**%d / %s does not fit in a felt", &num, scale, &den)
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	dstVal := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(value))
+	return vm.Memory.WriteToAddress(&dstAddr, &dstVal)
+}
+
+// Computes value = quotient * modulus + remainder with 0 <= remainder < modulus, as
+// Cairo's `unsigned_div_rem` does for a runtime modulus. DivMod already divides the
+// canonical integer representatives of its operands, so it is reused as is
+type DivModFelt252 struct {
+	value     hinter.Reference
+	modulus   hinter.Reference
+	quotient  hinter.Reference
+	remainder hinter.Reference
+}
+
+func (hint *DivModFelt252) String() string {
+	return "DivModFelt252"
+}
+
+func (hint *DivModFelt252) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	return DivMod{
+		lhs:       hint.value,
+		rhs:       hint.modulus,
+		quotient:  hint.quotient,
+		remainder: hint.remainder,
+	}.Execute(vm, ctx)
+}
+
+type UpdateMaxAccessIndex struct{}
+
+func (hint *UpdateMaxAccessIndex) String() string {
+	return "UpdateMaxAccessIndex"
+}
+
+func (hint *UpdateMaxAccessIndex) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	//> max_access_index = max(max_access_index, current_access_index)
+
+	currentAccessIndex, err := hinter.GetVariableAs[*f.Element](&ctx.ScopeManager, "current_access_index")
+	if err != nil {
+		return err
+	}
+
+	// The first update initializes the running max
+	if _, err := ctx.ScopeManager.GetVariableValue("max_access_index"); err == nil {
+		maxAccessIndex, err := hinter.GetVariableAs[*f.Element](&ctx.ScopeManager, "max_access_index")
+		if err != nil {
+			return err
+		}
+		if currentAccessIndex.Cmp(maxAccessIndex) <= 0 {
+			return nil
+		}
+	}
+
+	return ctx.ScopeManager.AssignVariable("max_access_index", new(f.Element).Set(currentAccessIndex))
+}
+
+type InSet struct {
+	value hinter.Reference
+	set   []hinter.Immediate
+	dst   hinter.Reference
+}
+
+func (hint *InSet) String() string {
+	return "InSet"
+}
+
+func (hint *InSet) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand: %w", err)
+	}
+
+	isMember := mem.MemoryValueFromInt(0)
+	for i := range hint.set {
+		member := f.Element(hint.set[i])
+		if value.Equal(&member) {
+			isMember = mem.MemoryValueFromInt(1)
+			break
+		}
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	return vm.Memory.WriteToAddress(&dstAddr, &isMember)
+}
+
+type IntGcd struct {
+	lhs hinter.Reference
+	rhs hinter.Reference
+	dst hinter.Reference
+}
+
+func (hint *IntGcd) String() string {
+	return "IntGcd"
+}
+
+func (hint *IntGcd) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	lhs, err := hinter.ResolveAsFelt(vm, hint.lhs)
+	if err != nil {
+		return fmt.Errorf("resolve lhs operand: %w", err)
+	}
+
+	rhs, err := hinter.ResolveAsFelt(vm, hint.rhs)
+	if err != nil {
+		return fmt.Errorf("resolve rhs operand: %w", err)
+	}
+
+	// big.Int GCD already follows gcd(x, 0) = x and gcd(0, 0) = 0
+	var lhsBig, rhsBig big.Int
+	lhs.BigInt(&lhsBig)
+	rhs.BigInt(&rhsBig)
+	gcd := new(big.Int).GCD(nil, nil, &lhsBig, &rhsBig)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	gcdValue := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(gcd))
+	return vm.Memory.WriteToAddress(&dstAddr, &gcdValue)
+}
+
+// MulSplit computes the full product of two felts as integers and splits it into
+// 252-bit halves as product = lowHalf + high * 2**252. The high half is always smaller
+// than the field prime, but the low half may not be, so it is written as two 126-bit
+// limbs lowHalf = low + mid * 2**126.
+type MulSplit struct {
+	a    hinter.Reference
+	b    hinter.Reference
+	low  hinter.Reference
+	mid  hinter.Reference
+	high hinter.Reference
+}
+
+func (hint *MulSplit) String() string {
+	return "MulSplit"
+}
+
+func (hint *MulSplit) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	a, err := hinter.ResolveAsFelt(vm, hint.a)
+	if err != nil {
+		return fmt.Errorf("resolve a operand: %w", err)
+	}
+
+	b, err := hinter.ResolveAsFelt(vm, hint.b)
+	if err != nil {
+		return fmt.Errorf("resolve b operand: %w", err)
+	}
+
+	var aBig, bBig big.Int
+	a.BigInt(&aBig)
+	b.BigInt(&bBig)
+	product := new(big.Int).Mul(&aBig, &bBig)
+
+	mask126 := new(big.Int).Lsh(big.NewInt(1), 126)
+	mask126.Sub(mask126, big.NewInt(1))
+
+	limbs := []struct {
+		name  string
+		dst   hinter.Reference
+		value *big.Int
+	}{
+		{"low", hint.low, new(big.Int).And(product, mask126)},
+		{"mid", hint.mid, new(big.Int).And(new(big.Int).Rsh(product, 126), mask126)},
+		{"high", hint.high, new(big.Int).Rsh(product, 252)},
+	}
+	for _, limb := range limbs {
+		addr, err := limb.dst.Get(vm)
+		if err != nil {
+			return fmt.Errorf("get %s destination cell: %w", limb.name, err)
+		}
+		mv := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(limb.value))
+		if err := vm.Memory.WriteToAddress(&addr, &mv); err != nil {
+			return fmt.Errorf("write %s cell: %w", limb.name, err)
+		}
+	}
+	return nil
+}
+
+// EcAdd adds two affine points on the STARK curve. Following the convention of
+// the Cairo common library, (0, 0) stands for the point at infinity.
+type EcAdd struct {
+	p   hinter.Reference
+	q   hinter.Reference
+	dst hinter.Reference
+}
+
+func (hint *EcAdd) String() string {
+	return "EcAdd"
+}
+
+func (hint *EcAdd) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	px, py, err := readEcPoint(vm, hint.p, "p")
+	if err != nil {
+		return err
+	}
+
+	qx, qy, err := readEcPoint(vm, hint.q, "q")
+	if err != nil {
+		return err
+	}
+
+	dstAddr, err := hinter.ResolveAsAddress(vm, hint.dst)
+	if err != nil {
+		return fmt.Errorf("resolve dst pointer: %w", err)
+	}
+
+	var x, y f.Element
+	switch {
+	case px.IsZero() && py.IsZero():
+		x, y = qx, qy
+	case qx.IsZero() && qy.IsZero():
+		x, y = px, py
+	case px.Equal(&qx):
+		var negQy f.Element
+		negQy.Neg(&qy)
+		if py.Equal(&negQy) {
+			// p + (-p) and the doubling of a point with y = 0 are the point at infinity
+			break
+		}
+
+		slope := tangentSlope(&px, &py, &utils.Alpha)
+		x, y = addWithSlope(&px, &py, &qx, &slope)
+	default:
+		// slope = (py - qy) / (px - qx)
+		var slope, denom f.Element
+		slope.Sub(&py, &qy)
+		denom.Sub(&px, &qx)
+		slope.Div(&slope, &denom)
+
+		x, y = addWithSlope(&px, &py, &qx, &slope)
+	}
+
+	return vm.Memory.WriteConsecutiveValues(*dstAddr, []mem.MemoryValue{
+		mem.MemoryValueFromFieldElement(&x),
+		mem.MemoryValueFromFieldElement(&y),
+	})
+}
+
+// readEcPoint reads the (x, y) coordinates pointed to by ptr and checks that
+// they lie on the STARK curve, or are the point at infinity
+func readEcPoint(vm *VM.VirtualMachine, ptr hinter.Reference, name string) (f.Element, f.Element, error) {
+	xAddr, err := hinter.ResolveAsAddress(vm, ptr)
+	if err != nil {
+		return f.Element{}, f.Element{}, fmt.Errorf("resolve %s pointer: %w", name, err)
+	}
+	x, err := vm.Memory.ReadFromAddressAsElement(xAddr)
+	if err != nil {
+		return f.Element{}, f.Element{}, fmt.Errorf("read %s.x: %w", name, err)
+	}
+
+	yAddr, err := xAddr.AddOffset(1)
+	if err != nil {
+		return f.Element{}, f.Element{}, err
+	}
+	y, err := vm.Memory.ReadFromAddressAsElement(&yAddr)
+	if err != nil {
+		return f.Element{}, f.Element{}, fmt.Errorf("read %s.y: %w", name, err)
+	}
+
+	if x.IsZero() && y.IsZero() {
+		return x, y, nil
+	}
+
+	// y^2 = x^3 + alpha * x + beta
+	var lhs, rhs, ax f.Element
+	lhs.Square(&y)
+	rhs.Square(&x)
+	rhs.Mul(&rhs, &x)
+	ax.Mul(&utils.Alpha, &x)
+	rhs.Add(&rhs, &ax)
+	rhs.Add(&rhs, &utils.Beta)
+	if !lhs.Equal(&rhs) {
+		return f.Element{}, f.Element{}, fmt.Errorf("point %s(%s, %s) is not on the curve", name, &x, &y)
+	}
+
+	return x, y, nil
+}
+
+// addWithSlope completes a point addition once the slope is known:
+// x = slope^2 - px - qx and y = slope * (px - x) - py
+func addWithSlope(px, py, qx, slope *f.Element) (f.Element, f.Element) {
+	var x, y f.Element
+	x.Square(slope)
+	x.Sub(&x, px)
+	x.Sub(&x, qx)
+
+	y.Sub(px, &x)
+	y.Mul(&y, slope)
+	y.Sub(&y, py)
+
+	return x, y
+}
+
+type AssertAccessDelta struct {
+	delta hinter.Reference
+}
+
+func (hint *AssertAccessDelta) String() string {
+	return "AssertAccessDelta"
+}
+
+func (hint *AssertAccessDelta) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	delta, err := hinter.ResolveAsFelt(vm, hint.delta)
+	if err != nil {
+		return fmt.Errorf("resolve delta operand: %w", err)
+	}
+
+	// the squash loop visits access indices in increasing order, so a delta
+	// that is negative in the balanced representation means it went backwards
+	signedDelta := u.AsInt(delta)
+	if signedDelta.Sign() < 0 {
+		return fmt.Errorf("access delta %s is negative", &signedDelta)
+	}
+	return nil
+}
+
+type SlopeDenominatorInv struct {
+	x1  hinter.Reference
+	x2  hinter.Reference
+	dst hinter.Reference
+}
+
+func (hint *SlopeDenominatorInv) String() string {
+	return "SlopeDenominatorInv"
+}
+
+func (hint *SlopeDenominatorInv) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	x1, err := hinter.ResolveAsFelt(vm, hint.x1)
+	if err != nil {
+		return fmt.Errorf("resolve x1 operand: %w", err)
+	}
+
+	x2, err := hinter.ResolveAsFelt(vm, hint.x2)
+	if err != nil {
+		return fmt.Errorf("resolve x2 operand: %w", err)
+	}
+
+	if x1.Equal(x2) {
+		return fmt.Errorf("x1 and x2 are both %s: the chord is a vertical line", x1)
+	}
+
+	var inv f.Element
+	inv.Sub(x2, x1)
+	inv.Inverse(&inv)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	invValue := mem.MemoryValueFromFieldElement(&inv)
+	return vm.Memory.WriteToAddress(&dstAddr, &invValue)
+}
+
+type TangentSlope struct {
+	x   hinter.Reference
+	y   hinter.Reference
+	a   hinter.Immediate
+	dst hinter.Reference
+}
+
+func (hint *TangentSlope) String() string {
+	return "TangentSlope"
+}
+
+func (hint *TangentSlope) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	x, err := hinter.ResolveAsFelt(vm, hint.x)
+	if err != nil {
+		return fmt.Errorf("resolve x operand: %w", err)
+	}
+
+	y, err := hinter.ResolveAsFelt(vm, hint.y)
+	if err != nil {
+		return fmt.Errorf("resolve y operand: %w", err)
+	}
+
+	if y.IsZero() {
+		return fmt.Errorf("y is zero: the tangent at (%s, 0) is a vertical line", x)
+	}
+
+	a := f.Element(hint.a)
+	slope := tangentSlope(x, y, &a)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	slopeValue := mem.MemoryValueFromFieldElement(&slope)
+	return vm.Memory.WriteToAddress(&dstAddr, &slopeValue)
+}
+
+// tangentSlope returns (3 * x^2 + a) / (2 * y), the slope used to double (x, y).
+// The caller must make sure y is not zero
+func tangentSlope(x, y, a *f.Element) f.Element {
+	var slope, denom f.Element
+	slope.Square(x)
+	denom.SetUint64(3)
+	slope.Mul(&slope, &denom)
+	slope.Add(&slope, a)
+	denom.Double(y)
+	slope.Div(&slope, &denom)
+	return slope
+}
+
+// SplitIntoLimbs writes the n little-endian limbs of bitWidth bits each of value to
+// consecutive cells starting at dst
+type SplitIntoLimbs struct {
+	value    hinter.Reference
+	bitWidth uint
+	n        uint
+	dst      hinter.Reference
+}
+
+func (hint *SplitIntoLimbs) String() string {
+	return "SplitIntoLimbs"
+}
+
+func (hint *SplitIntoLimbs) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	if hint.bitWidth == 0 {
+		return fmt.Errorf("limb bit width must be positive")
+	}
+
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand: %w", err)
+	}
+
+	var valueBig big.Int
+	value.BigInt(&valueBig)
+	if uint(valueBig.BitLen()) > hint.bitWidth*hint.n {
+		return fmt.Errorf(
+			"value %s does not fit in %d limbs of %d bits", &valueBig, hint.n, hint.bitWidth,
+		)
+	}
+
+	mask := new(big.Int).Lsh(big.NewInt(1), hint.bitWidth)
+	mask.Sub(mask, big.NewInt(1))
+
+	limbs := make([]mem.MemoryValue, hint.n)
+	var limb big.Int
+	for i := range limbs {
+		limb.And(&valueBig, mask)
+		limbs[i] = mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(&limb))
+		valueBig.Rsh(&valueBig, hint.bitWidth)
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	return vm.Memory.WriteConsecutiveValues(dstAddr, limbs)
+}
+
+type EcAddFromSlope struct {
+	slope hinter.Reference
+	x1    hinter.Reference
+	y1    hinter.Reference
+	x2    hinter.Reference
+	x3Dst hinter.Reference
+	y3Dst hinter.Reference
+}
+
+func (hint *EcAddFromSlope) String() string {
+	return "EcAddFromSlope"
+}
+
+func (hint *EcAddFromSlope) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	slope, err := hinter.ResolveAsFelt(vm, hint.slope)
+	if err != nil {
+		return fmt.Errorf("resolve slope operand: %w", err)
+	}
+
+	x1, err := hinter.ResolveAsFelt(vm, hint.x1)
+	if err != nil {
+		return fmt.Errorf("resolve x1 operand: %w", err)
+	}
+
+	y1, err := hinter.ResolveAsFelt(vm, hint.y1)
+	if err != nil {
+		return fmt.Errorf("resolve y1 operand: %w", err)
+	}
+
+	x2, err := hinter.ResolveAsFelt(vm, hint.x2)
+	if err != nil {
+		return fmt.Errorf("resolve x2 operand: %w", err)
+	}
+
+	x3, y3 := addWithSlope(x1, y1, x2, slope)
+
+	x3Addr, err := hint.x3Dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get x3 destination cell: %w", err)
+	}
+	x3Value := mem.MemoryValueFromFieldElement(&x3)
+	err = vm.Memory.WriteToAddress(&x3Addr, &x3Value)
+	if err != nil {
+		return fmt.Errorf("write x3 cell: %w", err)
+	}
+
+	y3Addr, err := hint.y3Dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get y3 destination cell: %w", err)
+	}
+	y3Value := mem.MemoryValueFromFieldElement(&y3)
+	err = vm.Memory.WriteToAddress(&y3Addr, &y3Value)
+	if err != nil {
+		return fmt.Errorf("write y3 cell: %w", err)
+	}
+	return nil
+}
+
+// BuiltinUsageSummary writes, starting at the address dst points to, the number of
+// cells used by each builtin segment in allocation order. As when checking the used
+// cells at the end of a run, a segment usage is its effective length
+type BuiltinUsageSummary struct {
+	dst hinter.Reference
+}
+
+func (hint *BuiltinUsageSummary) String() string {
+	return "BuiltinUsageSummary"
+}
+
+func (hint *BuiltinUsageSummary) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	dstAddr, err := hinter.ResolveAsAddress(vm, hint.dst)
+	if err != nil {
+		return fmt.Errorf("resolve dst pointer: %w", err)
+	}
+
+	var usedCells []mem.MemoryValue
+	for _, segment := range vm.Memory.Segments {
+		if _, ok := segment.BuiltinRunner.(*mem.NoBuiltin); ok {
+			continue
+		}
+		usedCells = append(usedCells, mem.MemoryValueFromUint(segment.Len()))
+	}
+
+	return vm.Memory.WriteConsecutiveValues(*dstAddr, usedCells)
+}
+
+// AssertRcDecomposition checks that the len limbs at ptr, in little-endian order,
+// are each smaller than base and recompose to value
+type AssertRcDecomposition struct {
+	ptr   hinter.Reference
+	len   hinter.Reference
+	base  hinter.Reference
+	value hinter.Reference
+}
+
+func (hint *AssertRcDecomposition) String() string {
+	return "AssertRcDecomposition"
+}
+
+func (hint *AssertRcDecomposition) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	ptr, err := hinter.ResolveAsAddress(vm, hint.ptr)
+	if err != nil {
+		return fmt.Errorf("resolve limbs pointer: %w", err)
+	}
+
+	length, err := hinter.ResolveAsLength(vm, hint.len)
+	if err != nil {
+		return fmt.Errorf("resolve length: %w", err)
+	}
+
+	base, err := hinter.ResolveAsFelt(vm, hint.base)
+	if err != nil {
+		return fmt.Errorf("resolve base operand: %w", err)
+	}
+
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand: %w", err)
+	}
+
+	if base.IsZero() {
+		return fmt.Errorf("base cannot be zero")
+	}
+
+	limbs, err := vm.Memory.ResolveAsBigIntN(*ptr, int(length))
+	if err != nil {
+		return fmt.Errorf("read limbs: %w", err)
+	}
+
+	var baseBig, valueBig big.Int
+	base.BigInt(&baseBig)
+	value.BigInt(&valueBig)
+
+	// the recomposition is done over the integers so that limbs wrapping
+	// around the field prime are not accepted
+	recomposed := new(big.Int)
+	var limbBig big.Int
+	for i := len(limbs) - 1; i >= 0; i-- {
+		limbs[i].BigInt(&limbBig)
+		if limbBig.Cmp(&baseBig) >= 0 {
+			return fmt.Errorf("limb %d: %s is not smaller than base %s", i, &limbBig, &baseBig)
+		}
+		recomposed.Mul(recomposed, &baseBig)
+		recomposed.Add(recomposed, &limbBig)
+	}
+
+	if recomposed.Cmp(&valueBig) != 0 {
+		return fmt.Errorf("limbs recompose to %s, expected %s", recomposed, &valueBig)
+	}
+	return nil
+}
+
+type IsQuadraticResidue struct {
+	value hinter.Reference
+	dst   hinter.Reference
+}
+
+func (hint *IsQuadraticResidue) String() string {
+	return "IsQuadraticResidue"
+}
+
+func (hint *IsQuadraticResidue) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand: %w", err)
+	}
+
+	// zero is its own square root, so only a Legendre symbol of -1 means
+	// there is no root in the field
+	isResidue := mem.MemoryValueFromInt(1)
+	if value.Legendre() == -1 {
+		isResidue = mem.MemoryValueFromInt(0)
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	return vm.Memory.WriteToAddress(&dstAddr, &isResidue)
+}
+
+// PoseidonHashChain hashes the len felts at ptr with the Poseidon sponge used by
+// poseidon_hash_many: a rate of 2, the input padded with 1 and then 0 to an even length
+type PoseidonHashChain struct {
+	ptr hinter.Reference
+	len hinter.Reference
+	dst hinter.Reference
+}
+
+func (hint *PoseidonHashChain) String() string {
+	return "PoseidonHashChain"
+}
+
+func (hint *PoseidonHashChain) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	ptr, err := hinter.ResolveAsAddress(vm, hint.ptr)
+	if err != nil {
+		return fmt.Errorf("resolve input pointer: %w", err)
+	}
+
+	length, err := hinter.ResolveAsLength(vm, hint.len)
+	if err != nil {
+		return fmt.Errorf("resolve length: %w", err)
+	}
+
+	values, err := vm.Memory.ResolveAsBigIntN(*ptr, int(length))
+	if err != nil {
+		return fmt.Errorf("read values: %w", err)
+	}
+
+	one := f.One()
+	zero := f.Element{}
+	values = append(values, &one)
+	if len(values)%2 == 1 {
+		values = append(values, &zero)
+	}
+
+	state := []f.Element{{}, {}, {}}
+	for i := 0; i < len(values); i += 2 {
+		state[0].Add(&state[0], values[i])
+		state[1].Add(&state[1], values[i+1])
+		state = builtins.PoseidonPerm(&state[0], &state[1], &state[2])
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	digest := mem.MemoryValueFromFieldElement(&state[0])
+	return vm.Memory.WriteToAddress(&dstAddr, &digest)
+}
+
+type ModExp struct {
+	base     hinter.Reference
+	exponent hinter.Reference
+	modulus  hinter.Reference
+	dst      hinter.Reference
+}
+
+func (hint *ModExp) String() string {
+	return "ModExp"
+}
+
+func (hint *ModExp) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	baseFelt, err := hinter.ResolveAsFelt(vm, hint.base)
+	if err != nil {
+		return fmt.Errorf("resolve base operand: %w", err)
+	}
+
+	exponentFelt, err := hinter.ResolveAsFelt(vm, hint.exponent)
+	if err != nil {
+		return fmt.Errorf("resolve exponent operand: %w", err)
+	}
+
+	modulusFelt, err := hinter.ResolveAsFelt(vm, hint.modulus)
+	if err != nil {
+		return fmt.Errorf("resolve modulus operand: %w", err)
+	}
+
+	var base, exponent, modulus big.Int
+	baseFelt.BigInt(&base)
+	exponentFelt.BigInt(&exponent)
+	modulusFelt.BigInt(&modulus)
+
+	if modulus.Sign() == 0 {
+		return fmt.Errorf("modulus is zero")
+	}
+
+	// value = pow(base, exponent, modulus)
+	value := new(big.Int).Exp(&base, &exponent, &modulus)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	dstVal := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(value))
+	return vm.Memory.WriteToAddress(&dstAddr, &dstVal)
+}
+
+// PedersenHashArray hashes the len felts at ptr as Cairo's hash_array does: the
+// elements are folded with Pedersen starting from zero, and the length is hashed last
+type PedersenHashArray struct {
+	ptr hinter.Reference
+	len hinter.Reference
+	dst hinter.Reference
+}
+
+func (hint *PedersenHashArray) String() string {
+	return "PedersenHashArray"
+}
+
+func (hint *PedersenHashArray) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	ptr, err := hinter.ResolveAsAddress(vm, hint.ptr)
+	if err != nil {
+		return fmt.Errorf("resolve input pointer: %w", err)
+	}
+
+	length, err := hinter.ResolveAsLength(vm, hint.len)
+	if err != nil {
+		return fmt.Errorf("resolve length: %w", err)
+	}
+
+	values, err := vm.Memory.ResolveAsBigIntN(*ptr, int(length))
+	if err != nil {
+		return fmt.Errorf("read values: %w", err)
+	}
+
+	var digest f.Element
+	for _, value := range values {
+		digest = pedersenhash.Pedersen(&digest, value)
+	}
+	lengthFelt := new(f.Element).SetUint64(length)
+	digest = pedersenhash.Pedersen(&digest, lengthFelt)
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	digestValue := mem.MemoryValueFromFieldElement(&digest)
+	return vm.Memory.WriteToAddress(&dstAddr, &digestValue)
+}
+
+type AssertRangeInitialized struct {
+	ptr hinter.Reference
+	len hinter.Reference
+}
+
+func (hint *AssertRangeInitialized) String() string {
+	return "AssertRangeInitialized"
+}
+
+func (hint *AssertRangeInitialized) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	ptr, err := hinter.ResolveAsAddress(vm, hint.ptr)
+	if err != nil {
+		return fmt.Errorf("resolve range pointer: %w", err)
+	}
+
+	length, err := hinter.ResolveAsUint64(vm, hint.len)
+	if err != nil {
+		return fmt.Errorf("resolve length: %w", err)
+	}
+
+	// cells are only inspected, so builtin cells are not deduced along the way
+	for i := uint64(0); i < length; i++ {
+		addr := mem.MemoryAddress{SegmentIndex: ptr.SegmentIndex, Offset: ptr.Offset + i}
+		if !vm.Memory.KnownValueAtAddress(&addr) {
+			return fmt.Errorf("cell %s at index %d of the range is uninitialized", addr, i)
+		}
+	}
+	return nil
+}
+
+// MaterializeBuiltinOutputs reads the n cells at builtinPtr, which makes the builtin
+// deduce any output cell not yet known, and copies them to the range at dstPtr.
+// Mod builtins cannot deduce cells on read, so their operations are evaluated first,
+// which fills the inputs of the following instances and the values table
+type MaterializeBuiltinOutputs struct {
+	builtinPtr hinter.Reference
+	n          hinter.Reference
+	dstPtr     hinter.Reference
+}
+
+func (hint *MaterializeBuiltinOutputs) String() string {
+	return "MaterializeBuiltinOutputs"
+}
+
+func (hint *MaterializeBuiltinOutputs) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	builtinPtr, err := hinter.ResolveAsAddress(vm, hint.builtinPtr)
+	if err != nil {
+		return fmt.Errorf("resolve builtin pointer: %w", err)
+	}
+
+	n, err := hinter.ResolveAsLength(vm, hint.n)
+	if err != nil {
+		return fmt.Errorf("resolve n: %w", err)
+	}
+
+	dstPtr, err := hinter.ResolveAsAddress(vm, hint.dstPtr)
+	if err != nil {
+		return fmt.Errorf("resolve destination pointer: %w", err)
+	}
+
+	if err := fillModBuiltin(vm, *builtinPtr); err != nil {
+		return err
+	}
+
+	values, err := vm.Memory.GetConsecutiveMemoryValues(*builtinPtr, n)
+	if err != nil {
+		return fmt.Errorf("read builtin cells: %w", err)
+	}
+	return vm.Memory.WriteConsecutiveValues(*dstPtr, values)
+}
+
+// Evaluates the operations of the mod builtin instance at builtinPtr, if the segment
+// belongs to a mod builtin
+func fillModBuiltin(vm *VM.VirtualMachine, builtinPtr mem.MemoryAddress) error {
+	if builtinPtr.SegmentIndex < 0 || builtinPtr.SegmentIndex >= len(vm.Memory.Segments) {
+		return nil
+	}
+	runner, ok := vm.Memory.Segments[builtinPtr.SegmentIndex].BuiltinRunner.(*builtins.ModBuiltin)
+	if !ok {
+		return nil
+	}
+
+	modBuiltin, err := readModBuiltinStruct(vm, builtinPtr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", runner, err)
+	}
+
+	if runner.ModBuiltinType() == builtins.Mul {
+		return builtins.FillMemory(vm.Memory, mem.UnknownAddress, 0, builtinPtr, modBuiltin.n)
+	}
+	return builtins.FillMemory(vm.Memory, builtinPtr, modBuiltin.n, mem.UnknownAddress, 0)
+}
+
+type CountLeadingZeros struct {
+	value hinter.Reference
+	dst   hinter.Reference
+}
+
+func (hint *CountLeadingZeros) String() string {
+	return "CountLeadingZeros"
+}
+
+func (hint *CountLeadingZeros) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand: %w", err)
+	}
+
+	// leading zeros are counted over the 252-bit representation of the felt
+	var valueBig big.Int
+	value.BigInt(&valueBig)
+	leadingZeros := mem.MemoryValueFromInt(252 - valueBig.BitLen())
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	return vm.Memory.WriteToAddress(&dstAddr, &leadingZeros)
+}
+
+// BatchInvToScope stores the inverses of the len felts at ptr in scope as
+// "inverses", for hints that consume them without writing them to memory
+type BatchInvToScope struct {
+	ptr hinter.Reference
+	len hinter.Reference
+}
+
+func (hint *BatchInvToScope) String() string {
+	return "BatchInvToScope"
+}
+
+func (hint *BatchInvToScope) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	ptr, err := hinter.ResolveAsAddress(vm, hint.ptr)
+	if err != nil {
+		return fmt.Errorf("resolve source pointer: %w", err)
+	}
+
+	length, err := hinter.ResolveAsLength(vm, hint.len)
+	if err != nil {
+		return fmt.Errorf("resolve length: %w", err)
+	}
+
+	values, err := vm.Memory.ResolveAsBigIntN(*ptr, int(length))
+	if err != nil {
+		return fmt.Errorf("read values: %w", err)
+	}
+
+	inverses, err := batchInvert(values)
+	if err != nil {
+		return err
+	}
+
+	inversePtrs := make([]*f.Element, len(inverses))
+	for i := range inverses {
+		inversePtrs[i] = &inverses[i]
+	}
+	return ctx.ScopeManager.AssignVariable("inverses", inversePtrs)
+}
+
+type HashKind uint8
+
+const (
+	PedersenHashKind HashKind = iota
+	PoseidonHashKind
+)
+
+func (kind HashKind) String() string {
+	switch kind {
+	case PedersenHashKind:
+		return "Pedersen"
+	case PoseidonHashKind:
+		return "Poseidon"
+	default:
+		return "unknown"
+	}
+}
+
+// Hashes two felts with the hash function of the given kind
+func (kind HashKind) hash(x, y *f.Element) (f.Element, error) {
+	switch kind {
+	case PedersenHashKind:
+		return pedersenhash.Pedersen(x, y), nil
+	case PoseidonHashKind:
+		two := f.NewElement(2)
+		return builtins.PoseidonPerm(x, y, &two)[0], nil
+	default:
+		return f.Element{}, fmt.Errorf("unknown hash kind %d", kind)
+	}
+}
+
+// MerkleRoot builds a binary Merkle tree over the n leaves at leavesPtr and writes
+// its root to dst. Each parent is the hash of its left and right children. When n is
+// not a power of two, the leaves are padded with zeros up to the next power of two,
+// and a single leaf is its own root
+type MerkleRoot struct {
+	leavesPtr hinter.Reference
+	n         hinter.Reference
+	dst       hinter.Reference
+	hashKind  HashKind
+}
+
+func (hint *MerkleRoot) String() string {
+	return "MerkleRoot"
+}
+
+func (hint *MerkleRoot) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	leavesPtr, err := hinter.ResolveAsAddress(vm, hint.leavesPtr)
+	if err != nil {
+		return fmt.Errorf("resolve leaves pointer: %w", err)
+	}
+
+	n, err := hinter.ResolveAsLength(vm, hint.n)
+	if err != nil {
+		return fmt.Errorf("resolve number of leaves: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("cannot compute the Merkle root of zero leaves")
+	}
+
+	leaves, err := vm.Memory.ResolveAsBigIntN(*leavesPtr, int(n))
+	if err != nil {
+		return fmt.Errorf("read leaves: %w", err)
+	}
+
+	width := 1
+	for width < len(leaves) {
+		width *= 2
+	}
+	level := make([]f.Element, width)
+	for i, leaf := range leaves {
+		level[i] = *leaf
+	}
+
+	for len(level) > 1 {
+		for i := 0; i < len(level)/2; i++ {
+			level[i], err = hint.hashKind.hash(&level[2*i], &level[2*i+1])
+			if err != nil {
+				return err
+			}
+		}
+		level = level[:len(level)/2]
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+
+	root := mem.MemoryValueFromFieldElement(&level[0])
+	return vm.Memory.WriteToAddress(&dstAddr, &root)
+}
+
+// Reads a u256 given as its (low, high) 128-bit limbs
+func resolveUint256(vm *VM.VirtualMachine, low, high hinter.Reference) (uint256.Int, error) {
+	lowFelt, err := hinter.ResolveAsFelt(vm, low)
+	if err != nil {
+		return uint256.Int{}, fmt.Errorf("resolve low limb: %w", err)
+	}
+	highFelt, err := hinter.ResolveAsFelt(vm, high)
+	if err != nil {
+		return uint256.Int{}, fmt.Errorf("resolve high limb: %w", err)
+	}
+	if err := u.AssertU128(lowFelt); err != nil {
+		return uint256.Int{}, fmt.Errorf("low limb: %w", err)
+	}
+	if err := u.AssertU128(highFelt); err != nil {
+		return uint256.Int{}, fmt.Errorf("high limb: %w", err)
+	}
+	lowBits := lowFelt.Bits()
+	highBits := highFelt.Bits()
+	return uint256.Int{lowBits[0], lowBits[1], highBits[0], highBits[1]}, nil
+}
+
+// Writes the (low, high) 128-bit limbs of a u256 to the given cells
+func writeUint256(vm *VM.VirtualMachine, value *uint256.Int, dstLow, dstHigh hinter.Reference) error {
+	lowAddr, err := dstLow.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get low destination cell: %w", err)
+	}
+	highAddr, err := dstHigh.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get high destination cell: %w", err)
+	}
+
+	low := uint256.Int{value[0], value[1], 0, 0}
+	high := uint256.Int{value[2], value[3], 0, 0}
+	lowFelt := f.Element{}
+	lowFelt.SetBytes(low.Bytes())
+	highFelt := f.Element{}
+	highFelt.SetBytes(high.Bytes())
+
+	lowValue := mem.MemoryValueFromFieldElement(&lowFelt)
+	if err := vm.Memory.WriteToAddress(&lowAddr, &lowValue); err != nil {
+		return fmt.Errorf("write low limb: %w", err)
+	}
+	highValue := mem.MemoryValueFromFieldElement(&highFelt)
+	if err := vm.Memory.WriteToAddress(&highAddr, &highValue); err != nil {
+		return fmt.Errorf("write high limb: %w", err)
+	}
+	return nil
+}
+
+// U256Shl shifts the u256 (low, high) left by shift bits. Bits shifted past 256
+// are dropped, so a shift of 256 or more gives zero
+type U256Shl struct {
+	low     hinter.Reference
+	high    hinter.Reference
+	shift   hinter.Reference
+	dstLow  hinter.Reference
+	dstHigh hinter.Reference
+}
+
+func (hint *U256Shl) String() string {
+	return "U256Shl"
+}
+
+func (hint *U256Shl) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := resolveUint256(vm, hint.low, hint.high)
+	if err != nil {
+		return err
+	}
+	shift, err := hinter.ResolveAsUint64(vm, hint.shift)
+	if err != nil {
+		return fmt.Errorf("resolve shift: %w", err)
+	}
+
+	if shift >= 256 {
+		value.Clear()
+	} else {
+		value.Lsh(&value, uint(shift))
+	}
+	return writeUint256(vm, &value, hint.dstLow, hint.dstHigh)
+}
+
+// U256Shr shifts the u256 (low, high) right by shift bits. A shift of 256 or more
+// gives zero
+type U256Shr struct {
+	low     hinter.Reference
+	high    hinter.Reference
+	shift   hinter.Reference
+	dstLow  hinter.Reference
+	dstHigh hinter.Reference
+}
+
+func (hint *U256Shr) String() string {
+	return "U256Shr"
+}
+
+func (hint *U256Shr) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := resolveUint256(vm, hint.low, hint.high)
+	if err != nil {
+		return err
+	}
+	shift, err := hinter.ResolveAsUint64(vm, hint.shift)
+	if err != nil {
+		return fmt.Errorf("resolve shift: %w", err)
+	}
+
+	if shift >= 256 {
+		value.Clear()
+	} else {
+		value.Rsh(&value, uint(shift))
+	}
+	return writeUint256(vm, &value, hint.dstLow, hint.dstHigh)
+}
+
+// RationalReconstruct recovers the fraction num / den represented by a field element,
+// where |num| and den are both at most sqrt(P / 2), the bound under which such a
+// fraction is unique. It runs the extended Euclidean algorithm on (P, value), whose
+// continued fraction convergents keep remainder ≡ coefficient * value (mod P), and
+// stops at the first remainder within the bound. The numerator is written as a felt,
+// so negative fractions have it stored as P - |num|
+type RationalReconstruct struct {
+	value  hinter.Reference
+	numDst hinter.Reference
+	denDst hinter.Reference
+}
+
+func (hint *RationalReconstruct) String() string {
+	return "RationalReconstruct"
+}
+
+func (hint *RationalReconstruct) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	valueFelt, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value: %w", err)
+	}
+
+	prime := f.Modulus()
+	bound := new(big.Int).Rsh(prime, 1)
+	bound.Sqrt(bound)
+
+	r0, r1 := new(big.Int).Set(prime), valueFelt.BigInt(new(big.Int))
+	t0, t1 := big.NewInt(0), big.NewInt(1)
+	quotient := new(big.Int)
+	for r1.Cmp(bound) > 0 {
+		quotient.Div(r0, r1)
+		r0, r1 = r1, r0.Sub(r0, new(big.Int).Mul(quotient, r1))
+		t0, t1 = t1, t0.Sub(t0, new(big.Int).Mul(quotient, t1))
+	}
+
+	num, den := r1, t1
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den.Neg(den)
+	}
+	if den.Sign() == 0 || den.Cmp(bound) > 0 || new(big.Int).GCD(nil, nil, new(big.Int).Abs(num), den).Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("%s is not a fraction with numerator and denominator bounded by %s", valueFelt, bound)
+	}
+
+	numFelt := new(f.Element).SetBigInt(num)
+	denFelt := new(f.Element).SetBigInt(den)
+
+	numAddr, err := hint.numDst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get numerator destination cell: %w", err)
+	}
+	numValue := mem.MemoryValueFromFieldElement(numFelt)
+	if err := vm.Memory.WriteToAddress(&numAddr, &numValue); err != nil {
+		return fmt.Errorf("write numerator: %w", err)
+	}
+
+	denAddr, err := hint.denDst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get denominator destination cell: %w", err)
+	}
+	denValue := mem.MemoryValueFromFieldElement(denFelt)
+	return vm.Memory.WriteToAddress(&denAddr, &denValue)
+}
+
+// AssertStride checks that a loop pointer advanced by exactly stride cells, i.e.
+// that curPtr == prevPtr + stride within the same segment
+type AssertStride struct {
+	prevPtr hinter.Reference
+	curPtr  hinter.Reference
+	stride  hinter.Reference
+}
+
+func (hint *AssertStride) String() string {
+	return "AssertStride"
+}
+
+func (hint *AssertStride) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	prevPtr, err := hinter.ResolveAsAddress(vm, hint.prevPtr)
+	if err != nil {
+		return fmt.Errorf("resolve previous pointer: %w", err)
+	}
+	curPtr, err := hinter.ResolveAsAddress(vm, hint.curPtr)
+	if err != nil {
+		return fmt.Errorf("resolve current pointer: %w", err)
+	}
+	stride, err := hinter.ResolveAsUint64(vm, hint.stride)
+	if err != nil {
+		return fmt.Errorf("resolve stride: %w", err)
+	}
+
+	if prevPtr.SegmentIndex != curPtr.SegmentIndex {
+		return fmt.Errorf("pointers %s and %s are in different segments", prevPtr, curPtr)
+	}
+	if curPtr.Offset != prevPtr.Offset+stride {
+		return fmt.Errorf("pointer advanced from %s to %s, expected a stride of %d", prevPtr, curPtr, stride)
+	}
+	return nil
+}
+
+// Reads a u384 given as four 96-bit limbs d0 + d1 * 2**96 + d2 * 2**192 + d3 * 2**288,
+// the UInt384 representation used by the mod builtins
+func resolveUint384(vm *VM.VirtualMachine, limbs [4]hinter.Reference, name string) (*big.Int, error) {
+	value := new(big.Int)
+	for i := len(limbs) - 1; i >= 0; i-- {
+		limb, err := hinter.ResolveAsFelt(vm, limbs[i])
+		if err != nil {
+			return nil, fmt.Errorf("resolve %s limb %d: %w", name, i, err)
+		}
+		limbBig := limb.BigInt(new(big.Int))
+		if limbBig.BitLen() > 96 {
+			return nil, fmt.Errorf("%s limb %d: %s does not fit in 96 bits", name, i, limb)
+		}
+		value.Lsh(value, 96)
+		value.Add(value, limbBig)
+	}
+	return value, nil
+}
+
+// Writes a u384 as four 96-bit limbs to the given cells
+func writeUint384(vm *VM.VirtualMachine, value *big.Int, dst [4]hinter.Reference, name string) error {
+	mask := new(big.Int).Lsh(big.NewInt(1), 96)
+	mask.Sub(mask, big.NewInt(1))
+
+	for i := range dst {
+		limb := new(big.Int).Rsh(value, uint(96*i))
+		limb.And(limb, mask)
+
+		addr, err := dst[i].Get(vm)
+		if err != nil {
+			return fmt.Errorf("get %s limb %d destination cell: %w", name, i, err)
+		}
+		mv := mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(limb))
+		if err := vm.Memory.WriteToAddress(&addr, &mv); err != nil {
+			return fmt.Errorf("write %s limb %d: %w", name, i, err)
+		}
+	}
+	return nil
+}
+
+// Uint384DivMod divides two u384 numbers given as four 96-bit limbs each, and writes
+// the limbs of the quotient and remainder
+type Uint384DivMod struct {
+	dividend  [4]hinter.Reference
+	divisor   [4]hinter.Reference
+	quotient  [4]hinter.Reference
+	remainder [4]hinter.Reference
+}
+
+func (hint *Uint384DivMod) String() string {
+	return "Uint384DivMod"
+}
+
+func (hint *Uint384DivMod) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	dividend, err := resolveUint384(vm, hint.dividend, "dividend")
+	if err != nil {
+		return err
+	}
+	divisor, err := resolveUint384(vm, hint.divisor, "divisor")
+	if err != nil {
+		return err
+	}
+	if divisor.Sign() == 0 {
+		return fmt.Errorf("cannot divide %s by a zero divisor", dividend)
+	}
+
+	quotient, remainder := new(big.Int).DivMod(dividend, divisor, new(big.Int))
+
+	if err := writeUint384(vm, quotient, hint.quotient, "quotient"); err != nil {
+		return err
+	}
+	return writeUint384(vm, remainder, hint.remainder, "remainder")
+}
+
+// Number of positions set for each value inserted in a bloom filter
+const bloomHashCount = 3
+
+// Bloom filters are arrays of size cells holding one bit each. Since memory is
+// write-once, a set bit is a cell holding 1 and an unset bit is a cell left
+// unwritten, so filters must not be pre-filled, e.g. by AllocZeroedSegment, as
+// BloomInsert could not set their bits. Size is bounded by hinter.MaxLength.
+// A value sets the bits at positions poseidon(value, i) mod size for i = 0, 1, 2,
+// where poseidon is the two-to-one Poseidon hash used by MerkleRoot
+func bloomPositions(vm *VM.VirtualMachine, filterPtr, size, value hinter.Reference) (*mem.MemoryAddress, []uint64, error) {
+	filter, err := hinter.ResolveAsAddress(vm, filterPtr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve filter pointer: %w", err)
+	}
+	filterSize, err := hinter.ResolveAsLength(vm, size)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve filter size: %w", err)
+	}
+	if filterSize == 0 {
+		return nil, nil, fmt.Errorf("bloom filter size is zero")
+	}
+	valueFelt, err := hinter.ResolveAsFelt(vm, value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve value: %w", err)
+	}
+
+	positions := make([]uint64, bloomHashCount)
+	sizeBig := new(big.Int).SetUint64(filterSize)
+	for i := range positions {
+		index := f.NewElement(uint64(i))
+		hash, err := PoseidonHashKind.hash(valueFelt, &index)
+		if err != nil {
+			return nil, nil, err
+		}
+		positions[i] = new(big.Int).Mod(hash.BigInt(new(big.Int)), sizeBig).Uint64()
+	}
+	return filter, positions, nil
+}
+
+// BloomInsert sets the bits of value in the bloom filter of size cells at filterPtr
+type BloomInsert struct {
+	filterPtr hinter.Reference
+	size      hinter.Reference
+	value     hinter.Reference
+}
+
+func (hint *BloomInsert) String() string {
+	return "BloomInsert"
+}
+
+func (hint *BloomInsert) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	filter, positions, err := bloomPositions(vm, hint.filterPtr, hint.size, hint.value)
+	if err != nil {
+		return err
+	}
+
+	bit := mem.MemoryValueFromInt(1)
+	for _, position := range positions {
+		addr := mem.MemoryAddress{SegmentIndex: filter.SegmentIndex, Offset: filter.Offset + position}
+		if err := vm.Memory.WriteToAddress(&addr, &bit); err != nil {
+			return fmt.Errorf("set bit %d: %w", position, err)
+		}
+	}
+	return nil
+}
+
+// BloomQuery writes 1 to dst if all the bits of value are set in the bloom filter of
+// size cells at filterPtr, meaning value was probably inserted, and 0 otherwise,
+// meaning it was certainly not
+type BloomQuery struct {
+	filterPtr hinter.Reference
+	size      hinter.Reference
+	value     hinter.Reference
+	dst       hinter.Reference
+}
+
+func (hint *BloomQuery) String() string {
+	return "BloomQuery"
+}
+
+func (hint *BloomQuery) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	filter, positions, err := bloomPositions(vm, hint.filterPtr, hint.size, hint.value)
+	if err != nil {
+		return err
+	}
+
+	bit := mem.MemoryValueFromInt(1)
+	found := mem.MemoryValueFromInt(1)
+	for _, position := range positions {
+		addr := mem.MemoryAddress{SegmentIndex: filter.SegmentIndex, Offset: filter.Offset + position}
+		if value, known := vm.Memory.PeekKnown(addr); !known || !value.Equal(&bit) {
+			found = mem.MemoryValueFromInt(0)
+			break
+		}
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	return vm.Memory.WriteToAddress(&dstAddr, &found)
+}
+
+// AssertCircuitOutput checks the output of a mod builtin circuit: the UInt384 at
+// offset cells from valuesPtr, whose limbs must fit in 96 bits, must be congruent to
+// expected modulo modulus
+type AssertCircuitOutput struct {
+	valuesPtr hinter.Reference
+	offset    hinter.Reference
+	expected  hinter.Reference
+	modulus   hinter.Reference
+}
+
+func (hint *AssertCircuitOutput) String() string {
+	return "AssertCircuitOutput"
+}
+
+func (hint *AssertCircuitOutput) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	valuesPtr, err := hinter.ResolveAsAddress(vm, hint.valuesPtr)
+	if err != nil {
+		return fmt.Errorf("resolve values pointer: %w", err)
+	}
+	offset, err := hinter.ResolveAsUint64(vm, hint.offset)
+	if err != nil {
+		return fmt.Errorf("resolve offset: %w", err)
+	}
+	expectedFelt, err := hinter.ResolveAsFelt(vm, hint.expected)
+	if err != nil {
+		return fmt.Errorf("resolve expected value: %w", err)
+	}
+	modulusFelt, err := hinter.ResolveAsFelt(vm, hint.modulus)
+	if err != nil {
+		return fmt.Errorf("resolve modulus: %w", err)
+	}
+	if modulusFelt.IsZero() {
+		return fmt.Errorf("modulus is zero")
+	}
+
+	outputAddr := mem.MemoryAddress{SegmentIndex: valuesPtr.SegmentIndex, Offset: valuesPtr.Offset + offset}
+	words, err := vm.Memory.ResolveAsBigIntN(outputAddr, builtins.N_WORDS)
+	if err != nil {
+		return fmt.Errorf("read circuit output at %s: %w", outputAddr, err)
+	}
+	output := new(big.Int)
+	for i := len(words) - 1; i >= 0; i-- {
+		limb := words[i].BigInt(new(big.Int))
+		if limb.BitLen() > 96 {
+			return fmt.Errorf("circuit output limb %d: %s does not fit in 96 bits", i, limb)
+		}
+		output.Lsh(output, 96)
+		output.Add(output, limb)
+	}
+
+	// the mod builtins only bound their outputs by a multiple of the modulus, so both
+	// sides are reduced before comparing them
+	modulus := modulusFelt.BigInt(new(big.Int))
+	output.Mod(output, modulus)
+	expected := expectedFelt.BigInt(new(big.Int))
+	expected.Mod(expected, modulus)
+	if output.Cmp(expected) != 0 {
+		return fmt.Errorf("circuit output at %s is %s, expected %s", outputAddr, output, expected)
+	}
+	return nil
+}
+
+// PointerOffset writes ptr - base as a felt, where ptr and base are addresses in the
+// same segment. Negative offsets wrap around the field, as in MemoryValue.Sub
+type PointerOffset struct {
+	ptr  hinter.Reference
+	base hinter.Reference
+	dst  hinter.Reference
+}
+
+func (hint *PointerOffset) String() string {
+	return "PointerOffset"
+}
+
+func (hint *PointerOffset) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	ptr, err := hint.ptr.Resolve(vm)
+	if err != nil {
+		return fmt.Errorf("resolve pointer operand %s: %w", hint.ptr, err)
+	}
+	if !ptr.IsAddress() {
+		return fmt.Errorf("pointer %s is not an address", &ptr)
+	}
+	base, err := hint.base.Resolve(vm)
+	if err != nil {
+		return fmt.Errorf("resolve base operand %s: %w", hint.base, err)
+	}
+	if !base.IsAddress() {
+		return fmt.Errorf("base %s is not an address", &base)
+	}
+
+	var offset mem.MemoryValue
+	if err := offset.Sub(&ptr, &base); err != nil {
+		return err
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	return vm.Memory.WriteToAddress(&dstAddr, &offset)
+}
+
+// LegendreSymbol writes the Legendre symbol of value modulo the field prime to dst:
+// 1 for a non-zero quadratic residue, 0 for zero and -1 for a non-residue
+type LegendreSymbol struct {
+	value hinter.Reference
+	dst   hinter.Reference
+}
+
+func (hint *LegendreSymbol) String() string {
+	return "LegendreSymbol"
+}
+
+func (hint *LegendreSymbol) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	value, err := hinter.ResolveAsFelt(vm, hint.value)
+	if err != nil {
+		return fmt.Errorf("resolve value operand %s: %w", hint.value, err)
+	}
+
+	symbol := mem.MemoryValueFromInt(value.Legendre())
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	return vm.Memory.WriteToAddress(&dstAddr, &symbol)
+}
+
+// BuildKeyIndexMap reads n dict accesses starting at accessPtr and stores in the
+// "key_to_indices" scope variable a map from each key to the indices of its accesses,
+// in the order they appear, with the same type as SquashedDictionaryManager.KeyToIndices.
+// Keys are compared by their field element value
+type BuildKeyIndexMap struct {
+	accessPtr hinter.Reference
+	n         hinter.Reference
+}
+
+func (hint *BuildKeyIndexMap) String() string {
+	return "BuildKeyIndexMap"
+}
+
+func (hint *BuildKeyIndexMap) Execute(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
+	accessPtr, err := hinter.ResolveAsAddress(vm, hint.accessPtr)
+	if err != nil {
+		return fmt.Errorf("resolve access pointer: %w", err)
+	}
+	n, err := hinter.ResolveAsLength(vm, hint.n)
+	if err != nil {
+		return fmt.Errorf("resolve number of accesses: %w", err)
+	}
+
+	const dictAccessSize = 3
+	sdm := hinter.SquashedDictionaryManager{KeyToIndices: make(map[f.Element][]uint64)}
+	for i := uint64(0); i < n; i++ {
+		keyAddr := mem.MemoryAddress{
+			SegmentIndex: accessPtr.SegmentIndex,
+			Offset:       accessPtr.Offset + i*dictAccessSize,
+		}
+		key, err := vm.Memory.ReadFromAddressAsElement(&keyAddr)
+		if err != nil {
+			return fmt.Errorf("reading key at %s: %w", keyAddr, err)
+		}
+		sdm.Insert(&key, i)
+	}
+
+	return ctx.ScopeManager.AssignVariable("key_to_indices", sdm.KeyToIndices)
+}
+
+// AssertModN checks that the n field of the ModBuiltin struct at builtinPtr equals
+// expectedN, catching a mismatch between the hint immediates and the builtin inputs
+type AssertModN struct {
+	builtinPtr hinter.Reference
+	expectedN  hinter.Reference
+}
+
+func (hint *AssertModN) String() string {
+	return "AssertModN"
+}
+
+func (hint *AssertModN) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	builtinPtr, err := hinter.ResolveAsAddress(vm, hint.builtinPtr)
+	if err != nil {
+		return fmt.Errorf("resolve builtin pointer: %w", err)
+	}
+	expectedN, err := hinter.ResolveAsFelt(vm, hint.expectedN)
+	if err != nil {
+		return fmt.Errorf("resolve expected n operand %s: %w", hint.expectedN, err)
+	}
+
+	nAddr, err := builtinPtr.AddOffset(builtins.N_OFFSET)
+	if err != nil {
+		return err
+	}
+	n, err := vm.Memory.ReadFromAddressAsElement(&nAddr)
+	if err != nil {
+		return fmt.Errorf("reading n at %s: %w", nAddr, err)
+	}
+
+	if !n.Equal(expectedN) {
+		return fmt.Errorf("mod builtin n is %s, expected %s", &n, expectedN)
+	}
+	return nil
+}
+
+// RootsOfUnity writes the order-th roots of unity of the field to consecutive cells
+// starting at dstPtr, as successive powers of the primitive root 3^((P-1)/order).
+// It errors if order does not divide P-1 or is larger than hinter.MaxLength
+type RootsOfUnity struct {
+	order  hinter.Reference
+	dstPtr hinter.Reference
+}
+
+func (hint *RootsOfUnity) String() string {
+	return "RootsOfUnity"
+}
+
+func (hint *RootsOfUnity) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	order, err := hinter.ResolveAsLength(vm, hint.order)
+	if err != nil {
+		return fmt.Errorf("resolve order operand %s: %w", hint.order, err)
+	}
+	dstPtr, err := hinter.ResolveAsAddress(vm, hint.dstPtr)
+	if err != nil {
+		return fmt.Errorf("resolve destination pointer: %w", err)
+	}
+
+	if order == 0 {
+		return fmt.Errorf("order must be positive")
+	}
+	groupOrder := new(big.Int).Sub(f.Modulus(), big.NewInt(1))
+	exponent, remainder := new(big.Int).QuoRem(groupOrder, new(big.Int).SetUint64(order), new(big.Int))
+	if remainder.Sign() != 0 {
+		return fmt.Errorf("order %d does not divide the multiplicative group order %s", order, groupOrder)
+	}
+
+	// 3 generates the multiplicative group of the field
+	generator := f.NewElement(3)
+	var root f.Element
+	root.Exp(generator, exponent)
+
+	power := f.One()
+	for i := uint64(0); i < order; i++ {
+		value := mem.MemoryValueFromFieldElement(&power)
+		if err := vm.Memory.Write(dstPtr.SegmentIndex, dstPtr.Offset+i, &value); err != nil {
+			return err
+		}
+		power.Mul(&power, &root)
+	}
+	return nil
+}
+
+// U256AddCarry adds the u256 values (aLow, aHigh) and (bLow, bHigh), writing the sum
+// modulo 2^256 to (dstLow, dstHigh) and 1 to dstCarry if it overflowed, 0 otherwise.
+// This matches u256_overflowing_add
+type U256AddCarry struct {
+	aLow     hinter.Reference
+	aHigh    hinter.Reference
+	bLow     hinter.Reference
+	bHigh    hinter.Reference
+	dstLow   hinter.Reference
+	dstHigh  hinter.Reference
+	dstCarry hinter.Reference
+}
+
+func (hint *U256AddCarry) String() string {
+	return "U256AddCarry"
+}
+
+func (hint *U256AddCarry) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	a, err := resolveUint256(vm, hint.aLow, hint.aHigh)
+	if err != nil {
+		return fmt.Errorf("resolve a: %w", err)
+	}
+	b, err := resolveUint256(vm, hint.bLow, hint.bHigh)
+	if err != nil {
+		return fmt.Errorf("resolve b: %w", err)
+	}
+
+	var sum uint256.Int
+	_, overflow := sum.AddOverflow(&a, &b)
+	if err := writeUint256(vm, &sum, hint.dstLow, hint.dstHigh); err != nil {
+		return err
+	}
+
+	carryAddr, err := hint.dstCarry.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get carry destination cell: %w", err)
+	}
+	var carry mem.MemoryValue
+	if overflow {
+		carry = mem.MemoryValueFromInt(1)
+	} else {
+		carry = mem.MemoryValueFromInt(0)
+	}
+	return vm.Memory.WriteToAddress(&carryAddr, &carry)
+}
+
+// BitReversePermute copies the len values at srcPtr to dstPtr, moving the value at
+// index i to the index whose binary representation is i reversed over log2(len) bits.
+// len must be a power of two
+type BitReversePermute struct {
+	srcPtr hinter.Reference
+	len    hinter.Reference
+	dstPtr hinter.Reference
+}
+
+func (hint *BitReversePermute) String() string {
+	return "BitReversePermute"
+}
+
+func (hint *BitReversePermute) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	srcPtr, err := hinter.ResolveAsAddress(vm, hint.srcPtr)
+	if err != nil {
+		return fmt.Errorf("resolve source pointer: %w", err)
+	}
+	length, err := hinter.ResolveAsLength(vm, hint.len)
+	if err != nil {
+		return fmt.Errorf("resolve length operand %s: %w", hint.len, err)
+	}
+	dstPtr, err := hinter.ResolveAsAddress(vm, hint.dstPtr)
+	if err != nil {
+		return fmt.Errorf("resolve destination pointer: %w", err)
+	}
+
+	if length == 0 || length&(length-1) != 0 {
+		return fmt.Errorf("length %d is not a power of two", length)
+	}
+	logLength := bits.TrailingZeros64(length)
+
+	values, err := vm.Memory.GetConsecutiveMemoryValues(*srcPtr, length)
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < length; i++ {
+		reversed := bits.Reverse64(i) >> (64 - logLength)
+		if err := vm.Memory.Write(dstPtr.SegmentIndex, dstPtr.Offset+reversed, &values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConstraintCombine writes to dst the random linear combination
+// sum(coeffs[i] * evals[i] * point^i) of the n constraint evaluations at evalsPtr
+// with the coefficients at coeffsPtr
+type ConstraintCombine struct {
+	evalsPtr  hinter.Reference
+	coeffsPtr hinter.Reference
+	n         hinter.Reference
+	point     hinter.Reference
+	dst       hinter.Reference
+}
+
+func (hint *ConstraintCombine) String() string {
+	return "ConstraintCombine"
+}
+
+func (hint *ConstraintCombine) Execute(vm *VM.VirtualMachine, _ *hinter.HintRunnerContext) error {
+	evalsPtr, err := hinter.ResolveAsAddress(vm, hint.evalsPtr)
+	if err != nil {
+		return fmt.Errorf("resolve evaluations pointer: %w", err)
+	}
+	coeffsPtr, err := hinter.ResolveAsAddress(vm, hint.coeffsPtr)
+	if err != nil {
+		return fmt.Errorf("resolve coefficients pointer: %w", err)
+	}
+	n, err := hinter.ResolveAsLength(vm, hint.n)
+	if err != nil {
+		return fmt.Errorf("resolve n operand %s: %w", hint.n, err)
+	}
+	point, err := hinter.ResolveAsFelt(vm, hint.point)
+	if err != nil {
+		return fmt.Errorf("resolve point operand %s: %w", hint.point, err)
+	}
+
+	evals, err := vm.Memory.ResolveAsBigIntN(*evalsPtr, int(n))
+	if err != nil {
+		return fmt.Errorf("read evaluations: %w", err)
+	}
+	coeffs, err := vm.Memory.ResolveAsBigIntN(*coeffsPtr, int(n))
+	if err != nil {
+		return fmt.Errorf("read coefficients: %w", err)
+	}
+
+	var combination, term f.Element
+	power := f.One()
+	for i := range evals {
+		term.Mul(coeffs[i], evals[i])
+		term.Mul(&term, &power)
+		combination.Add(&combination, &term)
+		power.Mul(&power, point)
+	}
+
+	dstAddr, err := hint.dst.Get(vm)
+	if err != nil {
+		return fmt.Errorf("get destination cell: %w", err)
+	}
+	combinationValue := mem.MemoryValueFromFieldElement(&combination)
+	return vm.Memory.WriteToAddress(&dstAddr, &combinationValue)
+}
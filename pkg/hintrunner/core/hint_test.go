@@ -1,9 +1,9 @@
 package core
 
 import (
-	"io"
+	"bytes"
+	"math"
 	"math/big"
-	"os"
 	"testing"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/hintrunner/hinter"
@@ -47,6 +47,109 @@ func TestAllocSegment(t *testing.T) {
 
 }
 
+func TestAllocSegmentWithCapacity(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	segmentsBefore := len(vm.Memory.Segments)
+
+	hint := AllocSegmentWithCapacity{
+		Size: hinter.Immediate(f.NewElement(1000)),
+		Dst:  hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(t, segmentsBefore+1, len(vm.Memory.Segments))
+
+	segmentAddr := mem.MemoryAddress{SegmentIndex: segmentsBefore, Offset: 0}
+	require.Equal(
+		t,
+		mem.MemoryValueFromMemoryAddress(&segmentAddr),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+	)
+
+	// no cell is written up front
+	segment := vm.Memory.Segments[segmentsBefore]
+	require.Equal(t, uint64(0), segment.Len())
+	require.Equal(t, 1000, cap(segment.Data))
+
+	// writing at the highest offset reuses the preallocated backing array
+	backingArray := &segment.Data[:cap(segment.Data)][0]
+	utils.WriteTo(vm, segmentsBefore, 999, mem.MemoryValueFromInt(7))
+	require.Same(t, backingArray, &segment.Data[0])
+	require.Equal(t, 1000, cap(segment.Data))
+	require.Equal(t, uint64(1000), segment.Len())
+	require.Equal(t, mem.MemoryValueFromInt(7), utils.ReadFrom(vm, segmentsBefore, 999))
+}
+
+func TestAllocSegmentWithCapacityTooLarge(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	segmentsBefore := len(vm.Memory.Segments)
+
+	// 2**63 would become a negative int capacity
+	hint := AllocSegmentWithCapacity{
+		Size: hinter.Immediate(f.NewElement(1 << 63)),
+		Dst:  hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the maximum")
+	require.Equal(t, segmentsBefore, len(vm.Memory.Segments))
+}
+
+func TestAllocZeroedSegment(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	segmentsBefore := len(vm.Memory.Segments)
+
+	hint := AllocZeroedSegment{
+		Size: hinter.Immediate(f.NewElement(5)),
+		Dst:  hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(t, segmentsBefore+1, len(vm.Memory.Segments))
+
+	segmentAddr := mem.MemoryAddress{SegmentIndex: segmentsBefore, Offset: 0}
+	require.Equal(
+		t,
+		mem.MemoryValueFromMemoryAddress(&segmentAddr),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+	)
+
+	require.Equal(t, uint64(5), vm.Memory.Segments[segmentsBefore].Len())
+	for i := uint64(0); i < 5; i++ {
+		value, err := vm.Memory.Read(segmentsBefore, i)
+		require.NoError(t, err)
+		require.Equal(t, mem.MemoryValueFromInt(0), value)
+	}
+}
+
+func TestAllocZeroedSegmentTooLarge(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	segmentsBefore := len(vm.Memory.Segments)
+
+	hint := AllocZeroedSegment{
+		Size: hinter.Immediate(f.NewElement(1 << 63)),
+		Dst:  hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the maximum")
+	require.Equal(t, segmentsBefore, len(vm.Memory.Segments))
+}
+
 func TestTestLessThanTrue(t *testing.T) {
 	vm := VM.DefaultVirtualMachine()
 	vm.Context.Ap = 0
@@ -1021,9 +1124,95 @@ func TestEvalCircuit(t *testing.T) {
 		}
 
 		err := hint.Execute(vm, nil)
+		require.ErrorContains(t, err, "AddMod operation 0 with offsets (lhs: 0, rhs: 12, res: 4)")
 		require.ErrorContains(t, err, "expected integer at address")
 	})
 
+	t.Run("test mod_builtin_runner (zero modulus)", func(t *testing.T) {
+		vm := VM.DefaultVirtualMachine()
+
+		vm.Context.Ap = 0
+		vm.Context.Fp = 0
+
+		AddModBuiltin := vm.Memory.AllocateBuiltinSegment(builtins.NewModBuiltin(1, 96, 1, builtins.Add))
+		MulModBuiltin := vm.Memory.AllocateBuiltinSegment(builtins.NewModBuiltin(1, 96, 1, builtins.Mul))
+
+		// add_mod_ptr
+		// p = UInt384(0,0,0,0)
+		utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 0, mem.MemoryValueFromInt(0))
+		utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 1, mem.MemoryValueFromInt(0))
+		utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 2, mem.MemoryValueFromInt(0))
+		utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 3, mem.MemoryValueFromInt(0))
+
+		// values_ptr
+		utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 4, mem.MemoryValueFromMemoryAddress(&mem.MemoryAddress{SegmentIndex: VM.ExecutionSegment, Offset: 0}))
+
+		// offsets_ptr
+		utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 5, mem.MemoryValueFromMemoryAddress(&mem.MemoryAddress{SegmentIndex: VM.ExecutionSegment, Offset: 16}))
+
+		// n
+		utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 6, mem.MemoryValueFromInt(1))
+
+		// To get the address of mul_mod_ptr and add_mod_ptr
+		utils.WriteTo(vm, VM.ExecutionSegment, 22, mem.MemoryValueFromSegmentAndOffset(AddModBuiltin.SegmentIndex, 0))
+		utils.WriteTo(vm, VM.ExecutionSegment, 23, mem.MemoryValueFromSegmentAndOffset(MulModBuiltin.SegmentIndex, 0))
+
+		var addRef hinter.ApCellRef = 22
+		var mulRef hinter.ApCellRef = 23
+
+		hint := EvalCircuit{
+			AddModN:   hinter.Immediate(f.NewElement(1)),
+			AddModPtr: hinter.Deref{Deref: addRef},
+			MulModN:   hinter.Immediate(f.NewElement(0)),
+			MulModPtr: hinter.Deref{Deref: mulRef},
+		}
+
+		err := hint.Execute(vm, nil)
+		require.ErrorContains(t, err, "modulus is zero")
+	})
+
+	t.Run("test mod_builtin_runner (values_ptr is not an address)", func(t *testing.T) {
+		vm := VM.DefaultVirtualMachine()
+
+		vm.Context.Ap = 0
+		vm.Context.Fp = 0
+
+		AddModBuiltin := vm.Memory.AllocateBuiltinSegment(builtins.NewModBuiltin(1, 96, 1, builtins.Add))
+		MulModBuiltin := vm.Memory.AllocateBuiltinSegment(builtins.NewModBuiltin(1, 96, 1, builtins.Mul))
+
+		// mul_mod_ptr
+		// p = UInt384(1,1,0,0)
+		utils.WriteTo(vm, MulModBuiltin.SegmentIndex, 0, mem.MemoryValueFromInt(1))
+		utils.WriteTo(vm, MulModBuiltin.SegmentIndex, 1, mem.MemoryValueFromInt(1))
+		utils.WriteTo(vm, MulModBuiltin.SegmentIndex, 2, mem.MemoryValueFromInt(0))
+		utils.WriteTo(vm, MulModBuiltin.SegmentIndex, 3, mem.MemoryValueFromInt(0))
+
+		// values_ptr holds a felt instead of an address
+		utils.WriteTo(vm, MulModBuiltin.SegmentIndex, 4, mem.MemoryValueFromInt(0))
+
+		// offsets_ptr
+		utils.WriteTo(vm, MulModBuiltin.SegmentIndex, 5, mem.MemoryValueFromMemoryAddress(&mem.MemoryAddress{SegmentIndex: VM.ExecutionSegment, Offset: 16}))
+
+		// n
+		utils.WriteTo(vm, MulModBuiltin.SegmentIndex, 6, mem.MemoryValueFromInt(1))
+
+		// To get the address of mul_mod_ptr and add_mod_ptr
+		utils.WriteTo(vm, VM.ExecutionSegment, 22, mem.MemoryValueFromSegmentAndOffset(AddModBuiltin.SegmentIndex, 0))
+		utils.WriteTo(vm, VM.ExecutionSegment, 23, mem.MemoryValueFromSegmentAndOffset(MulModBuiltin.SegmentIndex, 0))
+
+		var addRef hinter.ApCellRef = 22
+		var mulRef hinter.ApCellRef = 23
+
+		hint := EvalCircuit{
+			AddModN:   hinter.Immediate(f.NewElement(0)),
+			AddModPtr: hinter.Deref{Deref: addRef},
+			MulModN:   hinter.Immediate(f.NewElement(1)),
+			MulModPtr: hinter.Deref{Deref: mulRef},
+		}
+
+		err := hint.Execute(vm, nil)
+		require.EqualError(t, err, "MulMod builtin: values_ptr: memory value is not an address")
+	})
 }
 
 func TestU256InvModN(t *testing.T) {
@@ -1286,6 +1475,62 @@ func TestU256InvModN(t *testing.T) {
 	})
 }
 
+func TestUint256InvModNStrict(t *testing.T) {
+	t.Run("test uint256InvModNStrict (invertible)", func(t *testing.T) {
+		vm := VM.DefaultVirtualMachine()
+		vm.Context.Ap = 0
+		vm.Context.Fp = 0
+
+		// b = 3, n = 7: r = 5 and k = (5 * 3 - 1) / 7 = 2
+		hint := Uint256InvModNStrict{Uint256InvModN{
+			B0:        hinter.Immediate(f.NewElement(3)),
+			B1:        hinter.Immediate(f.NewElement(0)),
+			N0:        hinter.Immediate(f.NewElement(7)),
+			N1:        hinter.Immediate(f.NewElement(0)),
+			G0OrNoInv: hinter.ApCellRef(1),
+			G1Option:  hinter.ApCellRef(2),
+			SOrR0:     hinter.ApCellRef(3),
+			SOrR1:     hinter.ApCellRef(4),
+			TOrK0:     hinter.ApCellRef(5),
+			TOrK1:     hinter.ApCellRef(6),
+		}}
+
+		err := hint.Execute(vm, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, mem.MemoryValueFromInt(0), utils.ReadFrom(vm, VM.ExecutionSegment, 1))
+		require.Equal(t, mem.MemoryValueFromInt(5), utils.ReadFrom(vm, VM.ExecutionSegment, 3))
+		require.Equal(t, mem.MemoryValueFromInt(0), utils.ReadFrom(vm, VM.ExecutionSegment, 4))
+		require.Equal(t, mem.MemoryValueFromInt(2), utils.ReadFrom(vm, VM.ExecutionSegment, 5))
+		require.Equal(t, mem.MemoryValueFromInt(0), utils.ReadFrom(vm, VM.ExecutionSegment, 6))
+	})
+
+	t.Run("test uint256InvModNStrict (not invertible)", func(t *testing.T) {
+		vm := VM.DefaultVirtualMachine()
+		vm.Context.Ap = 0
+		vm.Context.Fp = 0
+
+		hint := Uint256InvModNStrict{Uint256InvModN{
+			B0:        hinter.Immediate(f.NewElement(6)),
+			B1:        hinter.Immediate(f.NewElement(0)),
+			N0:        hinter.Immediate(f.NewElement(9)),
+			N1:        hinter.Immediate(f.NewElement(0)),
+			G0OrNoInv: hinter.ApCellRef(1),
+			G1Option:  hinter.ApCellRef(2),
+			SOrR0:     hinter.ApCellRef(3),
+			SOrR1:     hinter.ApCellRef(4),
+			TOrK0:     hinter.ApCellRef(5),
+			TOrK1:     hinter.ApCellRef(6),
+		}}
+
+		err := hint.Execute(vm, nil)
+		require.EqualError(t, err, "6 has no inverse modulo 9: their gcd is 3")
+
+		// nothing is written on error
+		require.False(t, vm.Memory.KnownValueAtAddress(&mem.MemoryAddress{SegmentIndex: VM.ExecutionSegment, Offset: 1}))
+	})
+}
+
 func TestUint256DivMod(t *testing.T) {
 	t.Run("test uint256DivMod", func(t *testing.T) {
 		vm := VM.DefaultVirtualMachine()
@@ -1460,6 +1705,28 @@ func TestUint256DivModDivisionByZero(t *testing.T) {
 	require.ErrorContains(t, err, "cannot be divided by zero, divisor: 0")
 }
 
+func TestUint256DivModAddressOperand(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromSegmentAndOffset(2, 3))
+
+	hint := Uint256DivMod{
+		dividend0:  hinter.Immediate(f.NewElement(1)),
+		dividend1:  hinter.Immediate(f.NewElement(1)),
+		divisor0:   hinter.Deref{Deref: hinter.ApCellRef(0)},
+		divisor1:   hinter.Immediate(f.NewElement(1)),
+		quotient0:  hinter.ApCellRef(1),
+		quotient1:  hinter.ApCellRef(2),
+		remainder0: hinter.ApCellRef(3),
+		remainder1: hinter.ApCellRef(4),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "divisor0: memory value is not a field element")
+}
+
 func TestWideMul128IncorrectRange(t *testing.T) {
 	vm := VM.DefaultVirtualMachine()
 	vm.Context.Ap = 0
@@ -1487,11 +1754,6 @@ func TestWideMul128IncorrectRange(t *testing.T) {
 }
 
 func TestDebugPrint(t *testing.T) {
-	//Save the old stdout
-	rescueStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
 	vm := VM.DefaultVirtualMachine()
 	vm.Context.Ap = 0
 	vm.Context.Fp = 0
@@ -1506,20 +1768,78 @@ func TestDebugPrint(t *testing.T) {
 	var endRef hinter.ApCellRef = 1
 	start := hinter.Deref{Deref: starRef}
 	end := hinter.Deref{Deref: endRef}
+	out := bytes.Buffer{}
 	hint := DebugPrint{
-		start: start,
-		end:   end,
+		start:  start,
+		end:    end,
+		Writer: &out,
 	}
 	expected := []byte("[DEBUG] a\n[DEBUG] 14\n[DEBUG] 1e\n")
 	err := hint.Execute(vm, nil)
 
-	w.Close()
-	out, _ := io.ReadAll(r)
-	//Restore stdout at the end of the test
-	os.Stdout = rescueStdout
+	require.NoError(t, err)
+	require.Equal(t, expected, out.Bytes())
+}
+
+func TestDebugPrintString(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
 
+	// "hello" and "world!" packed as short strings
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromSegmentAndOffset(VM.ExecutionSegment, 3))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromSegmentAndOffset(VM.ExecutionSegment, 5))
+	utils.WriteTo(vm, VM.ExecutionSegment, 2, mem.MemoryValueFromSegmentAndOffset(VM.ExecutionSegment, 6))
+	utils.WriteTo(vm, VM.ExecutionSegment, 3, mem.MemoryValueFromInt(0x68656c6c6f))
+	utils.WriteTo(vm, VM.ExecutionSegment, 4, mem.MemoryValueFromInt(0x776f726c6421))
+	utils.WriteTo(vm, VM.ExecutionSegment, 5, mem.MemoryValueFromInt(0x68690a))
+
+	out := bytes.Buffer{}
+	hint := DebugPrintString{
+		start:  hinter.Deref{Deref: hinter.ApCellRef(0)},
+		end:    hinter.Deref{Deref: hinter.ApCellRef(1)},
+		Writer: &out,
+	}
+	err := hint.Execute(vm, nil)
 	require.NoError(t, err)
-	require.Equal(t, expected, out)
+	require.Equal(t, "[DEBUG] hello\n[DEBUG] world!\n", out.String())
+
+	// "hi\n" contains a line feed
+	hint = DebugPrintString{
+		start:  hinter.Deref{Deref: hinter.ApCellRef(1)},
+		end:    hinter.Deref{Deref: hinter.ApCellRef(2)},
+		Writer: &out,
+	}
+	err = hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "non-printable byte 0x0a")
+}
+
+func TestDebugPrintStringInvalidRange(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromSegmentAndOffset(VM.ExecutionSegment, 5))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromSegmentAndOffset(VM.ExecutionSegment, 3))
+	utils.WriteTo(vm, VM.ExecutionSegment, 2, mem.MemoryValueFromSegmentAndOffset(VM.ExecutionSegment, 1<<40))
+
+	out := bytes.Buffer{}
+	hint := DebugPrintString{
+		start:  hinter.Deref{Deref: hinter.ApCellRef(0)},
+		end:    hinter.Deref{Deref: hinter.ApCellRef(1)},
+		Writer: &out,
+	}
+	err := hint.Execute(vm, nil)
+	require.EqualError(t, err, "start 1:5 is after end 1:3")
+
+	hint = DebugPrintString{
+		start:  hinter.Deref{Deref: hinter.ApCellRef(1)},
+		end:    hinter.Deref{Deref: hinter.ApCellRef(2)},
+		Writer: &out,
+	}
+	err = hint.Execute(vm, nil)
+	require.EqualError(t, err, "range of 1099511627773 felts exceeds the maximum of 4194304")
+	require.Empty(t, out.String())
 }
 
 func TestSquareRoot(t *testing.T) {
@@ -1584,7 +1904,7 @@ func TestUint256SquareRootLow(t *testing.T) {
 		sqrtMul2MinusRemainderGeU128: sqrtMul2MinusRemainderGeU128,
 	}
 
-	err := hint.Execute(vm, nil)
+	err := hint.Execute(vm, hinter.InitializeDefaultContext())
 
 	require.NoError(t, err)
 
@@ -1631,7 +1951,7 @@ func TestUint256SquareRootHigh(t *testing.T) {
 		sqrtMul2MinusRemainderGeU128: sqrtMul2MinusRemainderGeU128,
 	}
 
-	err := hint.Execute(vm, nil)
+	err := hint.Execute(vm, hinter.InitializeDefaultContext())
 
 	require.NoError(t, err)
 
@@ -1678,7 +1998,8 @@ func TestUint256SquareRoot(t *testing.T) {
 		sqrtMul2MinusRemainderGeU128: sqrtMul2MinusRemainderGeU128,
 	}
 
-	err := hint.Execute(vm, nil)
+	ctx := hinter.InitializeDefaultContext()
+	err := hint.Execute(vm, ctx)
 
 	require.NoError(t, err)
 
@@ -1699,6 +2020,18 @@ func TestUint256SquareRoot(t *testing.T) {
 	require.Equal(t, expectedRemainderLow, actualRemainderLow)
 	require.Equal(t, expectedRemainderHigh, actualRemainderHigh)
 	require.Equal(t, expectedSqrtMul2MinusRemainderGeU128, actualSqrtMul2MinusRemainderGeU128)
+
+	remainder, err := hinter.GetVariableAs[*big.Int](&ctx.ScopeManager, "u256_sqrt_remainder")
+	require.NoError(t, err)
+
+	remainderLowFelt, err := actualRemainderLow.FieldElement()
+	require.NoError(t, err)
+	remainderHighFelt, err := actualRemainderHigh.FieldElement()
+	require.NoError(t, err)
+
+	expectedRemainder := new(big.Int).Lsh(remainderHighFelt.BigInt(new(big.Int)), 128)
+	expectedRemainder.Add(expectedRemainder, remainderLowFelt.BigInt(new(big.Int)))
+	require.Equal(t, 0, expectedRemainder.Cmp(remainder))
 }
 
 func TestUint512DivModByUint256(t *testing.T) {
@@ -1881,6 +2214,45 @@ func TestAllocConstantSize(t *testing.T) {
 	require.Equal(t, ctx.ConstantSizeSegment, mem.MemoryAddress{SegmentIndex: 2, Offset: 30})
 }
 
+func TestAllocConstantSizeStaleSegment(t *testing.T) {
+	testCases := []struct {
+		name        string
+		segment     mem.MemoryAddress
+		expectedErr string
+	}{
+		{
+			name:        "TestAllocConstantSizeUnallocatedSegment",
+			segment:     mem.MemoryAddress{SegmentIndex: 5, Offset: 10},
+			expectedErr: "constant size segment 5:10: segment is not allocated",
+		},
+		{
+			// the execution segment already holds the hint destination cell
+			name:        "TestAllocConstantSizeSegmentInUse",
+			segment:     mem.MemoryAddress{SegmentIndex: VM.ExecutionSegment, Offset: 0},
+			expectedErr: "constant size segment 1:0: segment already has 4 cells in use",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			utils.WriteTo(vm, VM.ExecutionSegment, 3, mem.MemoryValueFromInt(1))
+
+			ctx := hinter.HintRunnerContext{
+				ConstantSizeSegment: tc.segment,
+			}
+
+			hint := AllocConstantSize{
+				Dst:  hinter.ApCellRef(0),
+				Size: hinter.Immediate(f.NewElement(15)),
+			}
+
+			err := hint.Execute(vm, &ctx)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
 func TestAssertLeFindSmallArc(t *testing.T) {
 	testCases := []struct {
 		aFelt, bFelt                    f.Element
@@ -2018,6 +2390,72 @@ func TestAssertLeIsSecondArcExcluded(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 
+func TestAssertLeIsFirstArcExcluded128(t *testing.T) {
+	testCases := []struct {
+		excluded int
+		expected int
+	}{
+		{excluded: 0, expected: 0},
+		{excluded: 1, expected: 1},
+		{excluded: 2, expected: 1},
+	}
+
+	for _, tc := range testCases {
+		vm := VM.DefaultVirtualMachine()
+		vm.Context.Ap = 0
+		vm.Context.Fp = 0
+
+		ctx := hinter.SetContextWithScope(map[string]any{"excluded": tc.excluded})
+		hint := AssertLeIsFirstArcExcluded128{
+			SkipExcludeAFlag: hinter.ApCellRef(0),
+		}
+
+		err := hint.Execute(vm, ctx)
+		require.NoError(t, err)
+		require.Equal(t, mem.MemoryValueFromInt(tc.expected), utils.ReadFrom(vm, VM.ExecutionSegment, 0))
+	}
+}
+
+func TestAssertLeIsSecondArcExcluded128(t *testing.T) {
+	testCases := []struct {
+		excluded int
+		expected int
+	}{
+		{excluded: 0, expected: 1},
+		{excluded: 1, expected: 0},
+		{excluded: 2, expected: 1},
+	}
+
+	for _, tc := range testCases {
+		vm := VM.DefaultVirtualMachine()
+		vm.Context.Ap = 0
+		vm.Context.Fp = 0
+
+		ctx := hinter.SetContextWithScope(map[string]any{"excluded": tc.excluded})
+		hint := AssertLeIsSecondArcExcluded128{
+			SkipExcludeBMinusA: hinter.ApCellRef(0),
+		}
+
+		err := hint.Execute(vm, ctx)
+		require.NoError(t, err)
+		require.Equal(t, mem.MemoryValueFromInt(tc.expected), utils.ReadFrom(vm, VM.ExecutionSegment, 0))
+	}
+}
+
+func TestAssertLeIsArcExcluded128InvalidArc(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	ctx := hinter.SetContextWithScope(map[string]any{"excluded": 3})
+	hint := AssertLeIsFirstArcExcluded128{
+		SkipExcludeAFlag: hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, ctx)
+	require.EqualError(t, err, "excluded arc 3 is not one of the three arcs")
+}
+
 func TestRandomEcPoint(t *testing.T) {
 	vm := VM.DefaultVirtualMachine()
 	vm.Context.Ap = 0
@@ -2086,3 +2524,3777 @@ func TestFieldSqrt(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldSqrtStrict(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	hint := FieldSqrtStrict{
+		value: hinter.Immediate(f.NewElement(49)),
+		dst:   hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		mem.MemoryValueFromInt(7),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+	)
+}
+
+func TestFieldSqrtStrictNonResidue(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	hint := FieldSqrtStrict{
+		value: hinter.Immediate(f.NewElement(27)),
+		dst:   hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "27 is not a quadratic residue")
+}
+
+func TestConditionalSplit(t *testing.T) {
+	// value = 3 * 2**128 + 5
+	valueBig := new(big.Int).Lsh(big.NewInt(3), 128)
+	valueBig.Add(valueBig, big.NewInt(5))
+	valueFelt := new(f.Element).SetBigInt(valueBig)
+
+	testCases := []struct {
+		name         string
+		cond         uint64
+		expectedLow  mem.MemoryValue
+		expectedHigh mem.MemoryValue
+	}{
+		{
+			name:         "TestConditionalSplitCondNonZero",
+			cond:         1,
+			expectedLow:  mem.MemoryValueFromInt(5),
+			expectedHigh: mem.MemoryValueFromInt(3),
+		},
+		{
+			name:         "TestConditionalSplitCondZero",
+			cond:         0,
+			expectedLow:  mem.MemoryValueFromFieldElement(valueFelt),
+			expectedHigh: mem.MemoryValueFromInt(0),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := ConditionalSplit{
+				value:   hinter.Immediate(*valueFelt),
+				cond:    hinter.Immediate(f.NewElement(tc.cond)),
+				lowDst:  hinter.ApCellRef(0),
+				highDst: hinter.ApCellRef(1),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedLow, utils.ReadFrom(vm, VM.ExecutionSegment, 0))
+			require.Equal(t, tc.expectedHigh, utils.ReadFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        f.Element
+		b        f.Element
+		expected int
+	}{
+		{
+			name:     "TestHammingDistanceIdentical",
+			a:        f.NewElement(0xdeadbeef),
+			b:        f.NewElement(0xdeadbeef),
+			expected: 0,
+		},
+		{
+			// 0b1011 ^ 0b0110 = 0b1101
+			name:     "TestHammingDistanceDiffering",
+			a:        f.NewElement(0b1011),
+			b:        f.NewElement(0b0110),
+			expected: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := HammingDistance{
+				a:   hinter.Immediate(tc.a),
+				b:   hinter.Immediate(tc.b),
+				dst: hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromInt(tc.expected),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+			)
+		})
+	}
+}
+
+func TestBitwise(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	var dstAnd hinter.ApCellRef = 1
+	var dstOr hinter.ApCellRef = 2
+	var dstXor hinter.ApCellRef = 3
+
+	lhs := hinter.Immediate(f.NewElement(0b1100))
+	rhs := hinter.Immediate(f.NewElement(0b1010))
+
+	hint := Bitwise{
+		lhs: lhs,
+		rhs: rhs,
+		and: dstAnd,
+		or:  dstOr,
+		xor: dstXor,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	require.Equal(
+		t,
+		mem.MemoryValueFromInt(0b1000),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 1),
+	)
+	require.Equal(
+		t,
+		mem.MemoryValueFromInt(0b1110),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 2),
+	)
+	require.Equal(
+		t,
+		mem.MemoryValueFromInt(0b0110),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 3),
+	)
+}
+
+func TestBitwiseIncorrectRange(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// -1 is P - 1 which needs 252 bits
+	lhs := hinter.Immediate(f.NewElement(1))
+	rhs := hinter.Immediate(*new(f.Element).SetInt64(-1))
+
+	hint := Bitwise{
+		lhs: lhs,
+		rhs: rhs,
+		and: hinter.ApCellRef(1),
+		or:  hinter.ApCellRef(2),
+		xor: hinter.ApCellRef(3),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "should fit in 251 bits")
+}
+
+func TestExtendedGcdInv(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// 3 * 7 = 21 = 2 * 10 + 1
+	hint := ExtendedGcdInv{
+		a:   hinter.Immediate(f.NewElement(3)),
+		m:   hinter.Immediate(f.NewElement(10)),
+		dst: hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		mem.MemoryValueFromInt(7),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+	)
+}
+
+func TestExtendedGcdInvNotCoprime(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	hint := ExtendedGcdInv{
+		a:   hinter.Immediate(f.NewElement(4)),
+		m:   hinter.Immediate(f.NewElement(10)),
+		dst: hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "no inverse exists: gcd(4, 10) = 2")
+}
+
+func TestAssembleUInt384(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// UInt384(2,1,0,0)
+	limbs := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, limbs.SegmentIndex, 0, mem.MemoryValueFromInt(2))
+	utils.WriteTo(vm, limbs.SegmentIndex, 1, mem.MemoryValueFromInt(1))
+	utils.WriteTo(vm, limbs.SegmentIndex, 2, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, limbs.SegmentIndex, 3, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&limbs))
+
+	hint := AssembleUInt384{
+		limbsPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+
+	ctx := hinter.InitializeDefaultContext()
+	err := hint.Execute(vm, ctx)
+	require.NoError(t, err)
+
+	value, err := hinter.GetVariableAs[*big.Int](&ctx.ScopeManager, "value")
+	require.NoError(t, err)
+
+	// 2 + 2**96
+	expected := new(big.Int).Lsh(big.NewInt(1), 96)
+	expected.Add(expected, big.NewInt(2))
+	require.Equal(t, expected, value)
+}
+
+func TestAssembleUInt384LimbTooBig(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	limbs := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, limbs.SegmentIndex, 0, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, limbs.SegmentIndex, 1, mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(new(big.Int).Lsh(big.NewInt(1), 96))))
+	utils.WriteTo(vm, limbs.SegmentIndex, 2, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, limbs.SegmentIndex, 3, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&limbs))
+
+	hint := AssembleUInt384{
+		limbsPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+
+	err := hint.Execute(vm, hinter.InitializeDefaultContext())
+	require.ErrorContains(t, err, "limb 1")
+}
+
+func TestSplitUInt384(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// 5 + 3 * 2**96 + 7 * 2**192
+	valueBig := new(big.Int).Lsh(big.NewInt(7), 96)
+	valueBig.Add(valueBig, big.NewInt(3))
+	valueBig.Lsh(valueBig, 96)
+	valueBig.Add(valueBig, big.NewInt(5))
+
+	limbs := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&limbs))
+
+	hint := SplitUInt384{
+		value:  hinter.Immediate(*new(f.Element).SetBigInt(valueBig)),
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, mem.MemoryValueFromInt(5), utils.ReadFrom(vm, limbs.SegmentIndex, 0))
+	require.Equal(t, mem.MemoryValueFromInt(3), utils.ReadFrom(vm, limbs.SegmentIndex, 1))
+	require.Equal(t, mem.MemoryValueFromInt(7), utils.ReadFrom(vm, limbs.SegmentIndex, 2))
+	require.Equal(t, mem.MemoryValueFromInt(0), utils.ReadFrom(vm, limbs.SegmentIndex, 3))
+}
+
+func TestSplitUInt384RoundTrip(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// 2**383 + 2**200 + 17 does not fit in a felt so it is read from scope
+	valueBig := new(big.Int).Lsh(big.NewInt(1), 383)
+	valueBig.Add(valueBig, new(big.Int).Lsh(big.NewInt(1), 200))
+	valueBig.Add(valueBig, big.NewInt(17))
+
+	ctx := hinter.InitializeDefaultContext()
+	err := ctx.ScopeManager.AssignVariable("value", new(big.Int).Set(valueBig))
+	require.NoError(t, err)
+
+	limbs := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&limbs))
+
+	split := SplitUInt384{
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+	err = split.Execute(vm, ctx)
+	require.NoError(t, err)
+
+	assemble := AssembleUInt384{
+		limbsPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+	ctx = hinter.InitializeDefaultContext()
+	err = assemble.Execute(vm, ctx)
+	require.NoError(t, err)
+
+	value, err := hinter.GetVariableAs[*big.Int](&ctx.ScopeManager, "value")
+	require.NoError(t, err)
+	require.Equal(t, 0, valueBig.Cmp(value))
+}
+
+func TestSplitUInt384TooBig(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	ctx := hinter.InitializeDefaultContext()
+	err := ctx.ScopeManager.AssignVariable("value", new(big.Int).Lsh(big.NewInt(1), 384))
+	require.NoError(t, err)
+
+	limbs := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&limbs))
+
+	hint := SplitUInt384{
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+	err = hint.Execute(vm, ctx)
+	require.ErrorContains(t, err, "should fit in 384 bits")
+}
+
+func TestKeccakF1600(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	input := vm.Memory.AllocateEmptySegment()
+	output := vm.Memory.AllocateEmptySegment()
+	for i := uint64(0); i < 25; i++ {
+		utils.WriteTo(vm, input.SegmentIndex, i, mem.MemoryValueFromInt(0))
+	}
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&input))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&output))
+
+	hint := KeccakF1600{
+		inputPtr:  hinter.Deref{Deref: hinter.ApCellRef(0)},
+		outputPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	// Keccak-f[1600] applied to the all-zero state
+	expected := [25]uint64{
+		0xF1258F7940E1DDE7, 0x84D5CCF933C0478A, 0xD598261EA65AA9EE, 0xBD1547306F80494D, 0x8B284E056253D057,
+		0xFF97A42D7F8E6FD4, 0x90FEE5A0A44647C4, 0x8C5BDA0CD6192E76, 0xAD30A6F71B19059C, 0x30935AB7D08FFC64,
+		0xEB5AA93F2317D635, 0xA9A6E6260D712103, 0x81A57C16DBCF555F, 0x43B831CD0347C826, 0x01F22F1A11A5569F,
+		0x05E5635A21D9AE61, 0x64BEFEF28CC970F2, 0x613670957BC46611, 0xB87C5A554FD00ECB, 0x8C3EE88A1CCF32C8,
+		0x940C7922AE3A2614, 0x1841F924A2C509E4, 0x16F53526E70465C2, 0x75F644E97F30A13B, 0xEAF1FF7B5CECA249,
+	}
+	for i, lane := range expected {
+		require.Equal(t, mem.MemoryValueFromUint(lane), utils.ReadFrom(vm, output.SegmentIndex, uint64(i)))
+	}
+}
+
+func TestKeccakF1600LaneTooBig(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	input := vm.Memory.AllocateEmptySegment()
+	output := vm.Memory.AllocateEmptySegment()
+	// lane 3 is -1, which does not fit in u64
+	for i := uint64(0); i < 25; i++ {
+		lane := mem.MemoryValueFromInt(0)
+		if i == 3 {
+			lane = mem.MemoryValueFromInt(-1)
+		}
+		utils.WriteTo(vm, input.SegmentIndex, i, lane)
+	}
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&input))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&output))
+
+	hint := KeccakF1600{
+		inputPtr:  hinter.Deref{Deref: hinter.ApCellRef(0)},
+		outputPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "lane 3 should be u64")
+}
+
+func TestAddMod(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// p = 2^96 + 1, same modulus as the EvalCircuit tests
+	p := new(big.Int).Lsh(big.NewInt(1), 96)
+	p.Add(p, big.NewInt(1))
+
+	// (p - 11) + 17 = 6 (mod p)
+	a := new(big.Int).Sub(p, big.NewInt(11))
+
+	hint := AddMod{
+		a:       hinter.Immediate(*new(f.Element).SetBigInt(a)),
+		b:       hinter.Immediate(f.NewElement(17)),
+		modulus: hinter.Immediate(*new(f.Element).SetBigInt(p)),
+		dst:     hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		mem.MemoryValueFromInt(6),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+	)
+}
+
+func TestAddModZeroModulus(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	hint := AddMod{
+		a:       hinter.Immediate(f.NewElement(3)),
+		b:       hinter.Immediate(f.NewElement(4)),
+		modulus: hinter.Immediate(f.NewElement(0)),
+		dst:     hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "modulus is zero")
+}
+
+func TestMulMod(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        *big.Int
+		b        *big.Int
+		expected mem.MemoryValue
+	}{
+		{
+			// Same values as the first EvalCircuit test: 6 * 23 = 138
+			name:     "TestMulModNoReduction",
+			a:        big.NewInt(6),
+			b:        big.NewInt(23),
+			expected: mem.MemoryValueFromInt(138),
+		},
+		{
+			// 2^96 * 23 = -23 (mod 2^96 + 1)
+			name:     "TestMulModReduction",
+			a:        new(big.Int).Lsh(big.NewInt(1), 96),
+			b:        big.NewInt(23),
+			expected: mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 96), big.NewInt(22)))),
+		},
+	}
+
+	// p = 2^96 + 1, same modulus as the EvalCircuit tests
+	p := new(big.Int).Lsh(big.NewInt(1), 96)
+	p.Add(p, big.NewInt(1))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := MulMod{
+				a:       hinter.Immediate(*new(f.Element).SetBigInt(tc.a)),
+				b:       hinter.Immediate(*new(f.Element).SetBigInt(tc.b)),
+				modulus: hinter.Immediate(*new(f.Element).SetBigInt(p)),
+				dst:     hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, utils.ReadFrom(vm, VM.ExecutionSegment, 0))
+		})
+	}
+}
+
+func TestSignedDivMod(t *testing.T) {
+	testCases := []struct {
+		name              string
+		lhs               int64
+		rhs               int64
+		expectedQuotient  int
+		expectedRemainder int
+	}{
+		{
+			name:              "TestSignedDivModPositive",
+			lhs:               89,
+			rhs:               7,
+			expectedQuotient:  12,
+			expectedRemainder: 5,
+		},
+		{
+			name:              "TestSignedDivModNegativeDividend",
+			lhs:               -7,
+			rhs:               2,
+			expectedQuotient:  -4,
+			expectedRemainder: 1,
+		},
+		{
+			name:              "TestSignedDivModNegativeDivisor",
+			lhs:               7,
+			rhs:               -2,
+			expectedQuotient:  -4,
+			expectedRemainder: -1,
+		},
+		{
+			name:              "TestSignedDivModBothNegative",
+			lhs:               -7,
+			rhs:               -2,
+			expectedQuotient:  3,
+			expectedRemainder: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := SignedDivMod{
+				lhs:       hinter.Immediate(*new(f.Element).SetInt64(tc.lhs)),
+				rhs:       hinter.Immediate(*new(f.Element).SetInt64(tc.rhs)),
+				quotient:  hinter.ApCellRef(1),
+				remainder: hinter.ApCellRef(2),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, mem.MemoryValueFromInt(tc.expectedQuotient), utils.ReadFrom(vm, VM.ExecutionSegment, 1))
+			require.Equal(t, mem.MemoryValueFromInt(tc.expectedRemainder), utils.ReadFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestSignedDivModDivisionByZeroError(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	hint := SignedDivMod{
+		lhs:       hinter.Immediate(*new(f.Element).SetInt64(-43)),
+		rhs:       hinter.Immediate(f.NewElement(0)),
+		quotient:  hinter.ApCellRef(1),
+		remainder: hinter.ApCellRef(2),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "cannot be divided by zero, rhs: 0")
+}
+
+func TestComputeBarrettMu(t *testing.T) {
+	testCases := []struct {
+		name     string
+		modulus  *big.Int
+		expected *big.Int
+	}{
+		{
+			// 2**251 // 2**128 = 2**123
+			name:     "TestComputeBarrettMuPowerOfTwo",
+			modulus:  new(big.Int).Lsh(big.NewInt(1), 128),
+			expected: new(big.Int).Lsh(big.NewInt(1), 123),
+		},
+		{
+			// 2**251 // 1000 = 3618502788666131106986593281521497120414687020801267626233049500247285301
+			name:     "TestComputeBarrettMu",
+			modulus:  big.NewInt(1000),
+			expected: new(big.Int).Div(new(big.Int).Lsh(big.NewInt(1), 251), big.NewInt(1000)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := ComputeBarrettMu{
+				modulus: hinter.Immediate(*new(f.Element).SetBigInt(tc.modulus)),
+				dst:     hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(tc.expected)),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+			)
+		})
+	}
+}
+
+func TestValidateDictChain(t *testing.T) {
+	testCases := []struct {
+		name        string
+		accesses    [][3]int64
+		expectedErr string
+	}{
+		{
+			name: "TestValidateDictChainValid",
+			accesses: [][3]int64{
+				{1, 0, 10},
+				{2, 0, 20},
+				{1, 10, 11},
+				{2, 20, 21},
+				{1, 11, 12},
+			},
+		},
+		{
+			name: "TestValidateDictChainBrokenLink",
+			accesses: [][3]int64{
+				{1, 0, 10},
+				{2, 0, 20},
+				{1, 10, 11},
+				{2, 22, 23},
+			},
+			expectedErr: "access 3 for key 2: prev value 22 does not match previous new value 20",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			ctx := hinter.InitializeDefaultContext()
+			hinter.InitializeDictionaryManager(ctx, false)
+			dictAddr := ctx.DictionaryManager.NewDictionary(vm)
+
+			for i, access := range tc.accesses {
+				for j, value := range access {
+					utils.WriteTo(vm, dictAddr.SegmentIndex, uint64(i*3+j), mem.MemoryValueFromInt(value))
+				}
+			}
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&dictAddr))
+
+			hint := ValidateDictChain{
+				accessPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+				n:         hinter.Immediate(f.NewElement(uint64(len(tc.accesses)))),
+			}
+
+			err := hint.Execute(vm, ctx)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateDictChainTooManyAccesses(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	ctx := hinter.InitializeDefaultContext()
+	hinter.InitializeDictionaryManager(ctx, false)
+	dictAddr := ctx.DictionaryManager.NewDictionary(vm)
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&dictAddr))
+
+	// 3 * n wraps around to a small length
+	hint := ValidateDictChain{
+		accessPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		n:         hinter.Immediate(f.NewElement(0xaaaaaaaaaaaaaaab)),
+	}
+	require.ErrorContains(t, hint.Execute(vm, ctx), "exceeds the maximum")
+}
+
+func TestXorAccumulate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []int64
+		expected int64
+	}{
+		{
+			// 0b1010 ^ 0b0110 ^ 0b1111 ^ 0b0001 = 0b0010
+			name:     "TestXorAccumulate",
+			values:   []int64{10, 6, 15, 1},
+			expected: 2,
+		},
+		{
+			name:     "TestXorAccumulateEmpty",
+			values:   []int64{},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			values := vm.Memory.AllocateEmptySegment()
+			for i, value := range tc.values {
+				utils.WriteTo(vm, values.SegmentIndex, uint64(i), mem.MemoryValueFromInt(value))
+			}
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&values))
+
+			hint := XorAccumulate{
+				ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+				len: hinter.Immediate(f.NewElement(uint64(len(tc.values)))),
+				dst: hinter.ApCellRef(1),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromInt(tc.expected),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 1),
+			)
+		})
+	}
+}
+
+func TestXorAccumulateInvalidInputs(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// -1 is P - 1, which is wider than 251 bits
+	values := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, values.SegmentIndex, 0, mem.MemoryValueFromInt(3))
+	utils.WriteTo(vm, values.SegmentIndex, 1, mem.MemoryValueFromInt(-1))
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&values))
+
+	hint := XorAccumulate{
+		ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len: hinter.Immediate(f.NewElement(2)),
+		dst: hinter.ApCellRef(1),
+	}
+	require.ErrorContains(t, hint.Execute(vm, nil), "value 1: ")
+
+	hint.len = hinter.Immediate(f.NewElement(1 << 40))
+	require.ErrorContains(t, hint.Execute(vm, nil), "length 1099511627776 exceeds the maximum of 4194304")
+}
+
+func TestModInverse(t *testing.T) {
+	testCases := []struct {
+		name            string
+		value           uint64
+		modulus         uint64
+		expectedInverse int64
+		expectedFlag    int64
+	}{
+		{
+			// 3 * 7 = 21 = 2 * 10 + 1
+			name:            "TestModInverseCoprime",
+			value:           3,
+			modulus:         10,
+			expectedInverse: 7,
+			expectedFlag:    0,
+		},
+		{
+			name:            "TestModInverseNotCoprime",
+			value:           4,
+			modulus:         10,
+			expectedInverse: 0,
+			expectedFlag:    1,
+		},
+		{
+			// every value is congruent to 0 mod 1
+			name:            "TestModInverseModulusOne",
+			value:           5,
+			modulus:         1,
+			expectedInverse: 0,
+			expectedFlag:    0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := ModInverse{
+				value:         hinter.Immediate(f.NewElement(tc.value)),
+				modulus:       hinter.Immediate(f.NewElement(tc.modulus)),
+				dst:           hinter.ApCellRef(0),
+				notInvertible: hinter.ApCellRef(1),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromInt(tc.expectedInverse),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+			)
+			require.Equal(
+				t,
+				mem.MemoryValueFromInt(tc.expectedFlag),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 1),
+			)
+		})
+	}
+}
+
+func TestModInverseZeroModulus(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	hint := ModInverse{
+		value:         hinter.Immediate(f.NewElement(3)),
+		modulus:       hinter.Immediate(f.NewElement(0)),
+		dst:           hinter.ApCellRef(0),
+		notInvertible: hinter.ApCellRef(1),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "modulus is zero")
+}
+
+func TestIndex2D(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// 3x4 row-major matrix where m[i][j] = 10 * i + j
+	matrix := vm.Memory.AllocateEmptySegment()
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 4; j++ {
+			utils.WriteTo(vm, matrix.SegmentIndex, uint64(4*i+j), mem.MemoryValueFromInt(10*i+j))
+		}
+	}
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&matrix))
+
+	hint := Index2D{
+		base:   hinter.Deref{Deref: hinter.ApCellRef(0)},
+		row:    hinter.Immediate(f.NewElement(2)),
+		col:    hinter.Immediate(f.NewElement(1)),
+		stride: hinter.Immediate(f.NewElement(4)),
+		dst:    hinter.ApCellRef(1),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		mem.MemoryValueFromInt(21),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 1),
+	)
+}
+
+func TestIndex2DOverflow(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	matrix := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&matrix))
+
+	hint := Index2D{
+		base:   hinter.Deref{Deref: hinter.ApCellRef(0)},
+		row:    hinter.Immediate(f.NewElement(1 << 32)),
+		col:    hinter.Immediate(f.NewElement(0)),
+		stride: hinter.Immediate(f.NewElement(1 << 32)),
+		dst:    hinter.ApCellRef(1),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "offset overflow: 4294967296 * 4294967296")
+}
+
+func TestFieldInvArray(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	elements := []f.Element{f.NewElement(1), f.NewElement(2), f.NewElement(7), f.NewElement(12345)}
+	src := vm.Memory.AllocateEmptySegment()
+	dst := vm.Memory.AllocateEmptySegment()
+	for i := range elements {
+		utils.WriteTo(vm, src.SegmentIndex, uint64(i), mem.MemoryValueFromFieldElement(&elements[i]))
+	}
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&src))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&dst))
+
+	hint := FieldInvArray{
+		srcPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len:    hinter.Immediate(f.NewElement(uint64(len(elements)))),
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	for i := range elements {
+		expected := new(f.Element).Inverse(&elements[i])
+		require.Equal(
+			t,
+			mem.MemoryValueFromFieldElement(expected),
+			utils.ReadFrom(vm, dst.SegmentIndex, uint64(i)),
+		)
+	}
+}
+
+func TestFieldInvArrayZeroElement(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	src := vm.Memory.AllocateEmptySegment()
+	dst := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, src.SegmentIndex, 0, mem.MemoryValueFromInt(3))
+	utils.WriteTo(vm, src.SegmentIndex, 1, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, src.SegmentIndex, 2, mem.MemoryValueFromInt(5))
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&src))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&dst))
+
+	hint := FieldInvArray{
+		srcPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len:    hinter.Immediate(f.NewElement(3)),
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "element 1 is zero and has no inverse")
+}
+
+func TestFieldInvArrayLengthTooLarge(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	src := vm.Memory.AllocateEmptySegment()
+	dst := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&src))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&dst))
+
+	hint := FieldInvArray{
+		srcPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len:    hinter.Immediate(f.NewElement(1 << 40)),
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestSignOf(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    f.Element
+		expected mem.MemoryValue
+	}{
+		{
+			name:     "TestSignOfZero",
+			value:    f.NewElement(0),
+			expected: mem.MemoryValueFromInt(0),
+		},
+		{
+			name:     "TestSignOfPositive",
+			value:    f.NewElement(42),
+			expected: mem.MemoryValueFromInt(1),
+		},
+		{
+			// p - 1 is -1 in the balanced representation
+			name:     "TestSignOfNegative",
+			value:    *new(f.Element).SetInt64(-1),
+			expected: mem.MemoryValueFromInt(-1),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := SignOf{
+				value: hinter.Immediate(tc.value),
+				dst:   hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, utils.ReadFrom(vm, VM.ExecutionSegment, 0))
+		})
+	}
+}
+
+func TestIsU64(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    *big.Int
+		expected int64
+	}{
+		{
+			name:     "TestIsU64Max",
+			value:    new(big.Int).SetUint64(math.MaxUint64),
+			expected: 1,
+		},
+		{
+			name:     "TestIsU64TooBig",
+			value:    new(big.Int).Lsh(big.NewInt(1), 64),
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := IsU64{
+				value: hinter.Immediate(*new(f.Element).SetBigInt(tc.value)),
+				dst:   hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromInt(tc.expected),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+			)
+		})
+	}
+}
+
+func TestSqrtOrZero(t *testing.T) {
+	testCases := []struct {
+		name              string
+		value             uint64
+		expectedRoot      int
+		expectedIsResidue int
+	}{
+		{
+			name:              "TestSqrtOrZeroResidue",
+			value:             49,
+			expectedRoot:      7,
+			expectedIsResidue: 1,
+		},
+		{
+			name:              "TestSqrtOrZeroNonResidue",
+			value:             27,
+			expectedRoot:      0,
+			expectedIsResidue: 0,
+		},
+		{
+			name:              "TestSqrtOrZeroZero",
+			value:             0,
+			expectedRoot:      0,
+			expectedIsResidue: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := SqrtOrZero{
+				value:     hinter.Immediate(f.NewElement(tc.value)),
+				dst:       hinter.ApCellRef(0),
+				isResidue: hinter.ApCellRef(1),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromInt(tc.expectedRoot),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+			)
+			require.Equal(
+				t,
+				mem.MemoryValueFromInt(tc.expectedIsResidue),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 1),
+			)
+		})
+	}
+}
+
+func TestPowModSecp(t *testing.T) {
+	testCases := []struct {
+		name     string
+		base     [3]uint64
+		exp      uint64
+		expected *big.Int
+	}{
+		{
+			name:     "TestPowModSecpSmall",
+			base:     [3]uint64{3, 0, 0},
+			exp:      5,
+			expected: big.NewInt(243),
+		},
+		{
+			// 2**256 = 2**32 + 977 (mod SECP_P)
+			name:     "TestPowModSecpWrapsAround",
+			base:     [3]uint64{2, 0, 0},
+			exp:      256,
+			expected: big.NewInt(1<<32 + 977),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			for i, limb := range tc.base {
+				utils.WriteTo(vm, VM.ExecutionSegment, uint64(i), mem.MemoryValueFromUint(limb))
+			}
+
+			hint := PowModSecp{
+				base: hinter.ApCellRef(0),
+				exp:  hinter.Immediate(f.NewElement(tc.exp)),
+			}
+
+			ctx := hinter.InitializeDefaultContext()
+			err := hint.Execute(vm, ctx)
+			require.NoError(t, err)
+
+			value, err := hinter.GetVariableAs[*big.Int](&ctx.ScopeManager, "value")
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestReadModBuiltinStruct(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	modBuiltin := vm.Memory.AllocateBuiltinSegment(builtins.NewModBuiltin(1, 96, 1, builtins.Add))
+	valuesPtr := mem.MemoryAddress{SegmentIndex: VM.ExecutionSegment, Offset: 10}
+	offsetsPtr := mem.MemoryAddress{SegmentIndex: VM.ExecutionSegment, Offset: 20}
+
+	// p = UInt384(1,1,0,0) = 2**96 + 1
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 0, mem.MemoryValueFromInt(1))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 1, mem.MemoryValueFromInt(1))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 2, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 3, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 4, mem.MemoryValueFromMemoryAddress(&valuesPtr))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 5, mem.MemoryValueFromMemoryAddress(&offsetsPtr))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 6, mem.MemoryValueFromInt(3))
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&modBuiltin))
+
+	hint := ReadModBuiltinStruct{
+		ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+
+	ctx := hinter.InitializeDefaultContext()
+	err := hint.Execute(vm, ctx)
+	require.NoError(t, err)
+
+	p, err := hinter.GetVariableAs[*big.Int](&ctx.ScopeManager, "p")
+	require.NoError(t, err)
+	expectedP := new(big.Int).Lsh(big.NewInt(1), 96)
+	expectedP.Add(expectedP, big.NewInt(1))
+	require.Equal(t, expectedP, p)
+
+	values, err := hinter.GetVariableAs[mem.MemoryAddress](&ctx.ScopeManager, "values_ptr")
+	require.NoError(t, err)
+	require.Equal(t, valuesPtr, values)
+
+	offsets, err := hinter.GetVariableAs[mem.MemoryAddress](&ctx.ScopeManager, "offsets_ptr")
+	require.NoError(t, err)
+	require.Equal(t, offsetsPtr, offsets)
+
+	n, err := hinter.GetVariableAs[uint64](&ctx.ScopeManager, "n")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), n)
+}
+
+func TestReadModBuiltinStructMalformed(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	modBuiltin := vm.Memory.AllocateBuiltinSegment(builtins.NewModBuiltin(1, 96, 1, builtins.Add))
+	valuesPtr := mem.MemoryAddress{SegmentIndex: VM.ExecutionSegment, Offset: 10}
+
+	// offsets_ptr holds a felt instead of an address
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 0, mem.MemoryValueFromInt(1))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 1, mem.MemoryValueFromInt(1))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 2, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 3, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 4, mem.MemoryValueFromMemoryAddress(&valuesPtr))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 5, mem.MemoryValueFromInt(20))
+	utils.WriteTo(vm, modBuiltin.SegmentIndex, 6, mem.MemoryValueFromInt(3))
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&modBuiltin))
+
+	hint := ReadModBuiltinStruct{
+		ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+
+	err := hint.Execute(vm, hinter.InitializeDefaultContext())
+	require.EqualError(t, err, "offsets_ptr: memory value is not an address")
+}
+
+func TestInversePermutation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		permutation []int
+		expected    []int
+		expectedErr string
+	}{
+		{
+			name:        "TestInversePermutation",
+			permutation: []int{2, 0, 3, 1},
+			expected:    []int{1, 3, 0, 2},
+		},
+		{
+			name:        "TestInversePermutationDuplicate",
+			permutation: []int{2, 0, 2, 1},
+			expectedErr: "element 2: 2 appears more than once",
+		},
+		{
+			name:        "TestInversePermutationOutOfRange",
+			permutation: []int{2, 0, 4, 1},
+			expectedErr: "element 2: 4 is out of range [0, 4)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			src := vm.Memory.AllocateEmptySegment()
+			dst := vm.Memory.AllocateEmptySegment()
+			for i, index := range tc.permutation {
+				utils.WriteTo(vm, src.SegmentIndex, uint64(i), mem.MemoryValueFromInt(index))
+			}
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&src))
+			utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&dst))
+
+			hint := InversePermutation{
+				srcPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+				len:    hinter.Immediate(f.NewElement(uint64(len(tc.permutation)))),
+				dstPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			for i, index := range tc.expected {
+				require.Equal(
+					t,
+					mem.MemoryValueFromInt(index),
+					utils.ReadFrom(vm, dst.SegmentIndex, uint64(i)),
+				)
+			}
+		})
+	}
+}
+
+func TestInversePermutationLengthTooLarge(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	src := vm.Memory.AllocateEmptySegment()
+	dst := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&src))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&dst))
+
+	hint := InversePermutation{
+		srcPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len:    hinter.Immediate(f.NewElement(1 << 40)),
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestDivModAssert(t *testing.T) {
+	testCases := []struct {
+		name        string
+		lhs         uint64
+		rhs         uint64
+		quotient    uint64
+		remainder   uint64
+		expectedErr string
+	}{
+		{
+			name:      "TestDivModAssertValid",
+			lhs:       89,
+			rhs:       7,
+			quotient:  12,
+			remainder: 5,
+		},
+		{
+			name:        "TestDivModAssertTamperedQuotient",
+			lhs:         89,
+			rhs:         7,
+			quotient:    11,
+			remainder:   5,
+			expectedErr: "89 != 11 * 7 + 5",
+		},
+		{
+			// 89 = 11 * 7 + 12, but the remainder is not reduced
+			name:        "TestDivModAssertRemainderTooBig",
+			lhs:         89,
+			rhs:         7,
+			quotient:    11,
+			remainder:   12,
+			expectedErr: "remainder 12 should be smaller than rhs 7",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := DivModAssert{
+				lhs:       hinter.Immediate(f.NewElement(tc.lhs)),
+				rhs:       hinter.Immediate(f.NewElement(tc.rhs)),
+				quotient:  hinter.Immediate(f.NewElement(tc.quotient)),
+				remainder: hinter.Immediate(f.NewElement(tc.remainder)),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestFixedPointFromRatio(t *testing.T) {
+	testCases := []struct {
+		name     string
+		num      uint64
+		den      uint64
+		scale    uint64
+		expected *big.Int
+	}{
+		{
+			// 1/3 * 2**8 = 85.33...
+			name:     "TestFixedPointFromRatioScale8",
+			num:      1,
+			den:      3,
+			scale:    8,
+			expected: big.NewInt(85),
+		},
+		{
+			// 1/3 * 2**64 = 6148914691236517205.33...
+			name:     "TestFixedPointFromRatioScale64",
+			num:      1,
+			den:      3,
+			scale:    64,
+			expected: new(big.Int).SetUint64(6148914691236517205),
+		},
+		{
+			// 7/2 * 2**16 = 229376
+			name:     "TestFixedPointFromRatioExact",
+			num:      7,
+			den:      2,
+			scale:    16,
+			expected: big.NewInt(229376),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := FixedPointFromRatio{
+				num:   hinter.Immediate(f.NewElement(tc.num)),
+				den:   hinter.Immediate(f.NewElement(tc.den)),
+				scale: hinter.Immediate(f.NewElement(tc.scale)),
+				dst:   hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(tc.expected)),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+			)
+		})
+	}
+}
+
+func TestFixedPointFromRatioZeroDenominator(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	hint := FixedPointFromRatio{
+		num:   hinter.Immediate(f.NewElement(1)),
+		den:   hinter.Immediate(f.NewElement(0)),
+		scale: hinter.Immediate(f.NewElement(8)),
+		dst:   hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "cannot be divided by zero, den: 0")
+}
+
+func TestDivModFelt252(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// p - 1 = 3618502788666131213697322783095070105623107215331596699973092056135872020480
+	//       = 361850278866613121369732278309507010562310721533159669997309205613587202048 * 10 + 0
+	// so p - 2 leaves a remainder of 9 with a quotient one below
+	value := new(f.Element).SetInt64(-2)
+	expectedQuotient, _ := new(big.Int).SetString("361850278866613121369732278309507010562310721533159669997309205613587202047", 10)
+
+	hint := DivModFelt252{
+		value:     hinter.Immediate(*value),
+		modulus:   hinter.Immediate(f.NewElement(10)),
+		quotient:  hinter.ApCellRef(0),
+		remainder: hinter.ApCellRef(1),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		mem.MemoryValueFromFieldElement(new(f.Element).SetBigInt(expectedQuotient)),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+	)
+	require.Equal(
+		t,
+		mem.MemoryValueFromInt(9),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 1),
+	)
+}
+
+func TestDivModFelt252DivisionByZero(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	hint := DivModFelt252{
+		value:     hinter.Immediate(f.NewElement(89)),
+		modulus:   hinter.Immediate(f.NewElement(0)),
+		quotient:  hinter.ApCellRef(0),
+		remainder: hinter.ApCellRef(1),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "cannot be divided by zero, rhs: 0")
+}
+
+func TestFixedPointFromRatioScaleTooLarge(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	hint := FixedPointFromRatio{
+		num:   hinter.Immediate(f.NewElement(1)),
+		den:   hinter.Immediate(f.NewElement(3)),
+		scale: hinter.Immediate(f.NewElement(1 << 40)),
+		dst:   hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.EqualError(t, err, "scale 1099511627776 exceeds the maximum of 251")
+}
+
+func TestUpdateMaxAccessIndex(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	ctx := hinter.InitializeDefaultContext()
+
+	hint := UpdateMaxAccessIndex{}
+
+	accessIndices := []uint64{3, 1, 7, 7, 2}
+	expectedMax := []uint64{3, 3, 7, 7, 7}
+	for i, accessIndex := range accessIndices {
+		err := ctx.ScopeManager.AssignVariable("current_access_index", new(f.Element).SetUint64(accessIndex))
+		require.NoError(t, err)
+
+		err = hint.Execute(vm, ctx)
+		require.NoError(t, err)
+
+		maxAccessIndex, err := hinter.GetVariableAs[*f.Element](&ctx.ScopeManager, "max_access_index")
+		require.NoError(t, err)
+		require.Equal(t, new(f.Element).SetUint64(expectedMax[i]), maxAccessIndex)
+	}
+}
+
+func TestInSet(t *testing.T) {
+	set := []hinter.Immediate{
+		hinter.Immediate(f.NewElement(3)),
+		hinter.Immediate(f.NewElement(17)),
+		hinter.Immediate(f.NewElement(42)),
+	}
+
+	testCases := []struct {
+		name     string
+		value    uint64
+		expected int64
+	}{
+		{
+			name:     "TestInSetMember",
+			value:    17,
+			expected: 1,
+		},
+		{
+			name:     "TestInSetNonMember",
+			value:    5,
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := InSet{
+				value: hinter.Immediate(f.NewElement(tc.value)),
+				set:   set,
+				dst:   hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromInt(tc.expected),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+			)
+		})
+	}
+}
+
+func TestIntGcd(t *testing.T) {
+	testCases := []struct {
+		lhs, rhs, gcd uint64
+	}{
+		{lhs: 35, rhs: 64, gcd: 1},
+		{lhs: 17, rhs: 5, gcd: 1},
+		{lhs: 84, rhs: 36, gcd: 12},
+		{lhs: 1024, rhs: 48, gcd: 16},
+		{lhs: 9, rhs: 0, gcd: 9},
+		{lhs: 0, rhs: 0, gcd: 0},
+	}
+
+	for _, tc := range testCases {
+		vm := VM.DefaultVirtualMachine()
+		vm.Context.Ap = 0
+		vm.Context.Fp = 0
+
+		var dst hinter.ApCellRef = 1
+
+		lhsValue := hinter.Immediate(f.NewElement(tc.lhs))
+		rhsValue := hinter.Immediate(f.NewElement(tc.rhs))
+
+		hint := IntGcd{
+			lhs: lhsValue,
+			rhs: rhsValue,
+			dst: dst,
+		}
+
+		err := hint.Execute(vm, nil)
+		require.Nil(t, err)
+
+		expectedGcd := mem.MemoryValueFromUint(tc.gcd)
+		actualGcd := utils.ReadFrom(vm, VM.ExecutionSegment, 1)
+
+		require.Equal(t, expectedGcd, actualGcd)
+	}
+}
+
+func TestMulSplit(t *testing.T) {
+	modulus := f.Modulus()
+	maxFelt := new(big.Int).Sub(modulus, big.NewInt(1))
+
+	testCases := []struct {
+		name string
+		a    *big.Int
+		b    *big.Int
+	}{
+		{
+			name: "TestMulSplitSmall",
+			a:    big.NewInt(1 << 40),
+			b:    big.NewInt(12345),
+		},
+		{
+			name: "TestMulSplitNearMax",
+			a:    new(big.Int).Sub(modulus, big.NewInt(2)),
+			b:    new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 251), big.NewInt(3)),
+		},
+		{
+			name: "TestMulSplitMaxTimesPowerOfTwo",
+			a:    maxFelt,
+			b:    new(big.Int).Lsh(big.NewInt(1), 251),
+		},
+		{
+			// (P - 1)**2 has a low half above the prime
+			name: "TestMulSplitMaxSquared",
+			a:    maxFelt,
+			b:    maxFelt,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := MulSplit{
+				a:    hinter.Immediate(*new(f.Element).SetBigInt(tc.a)),
+				b:    hinter.Immediate(*new(f.Element).SetBigInt(tc.b)),
+				low:  hinter.ApCellRef(0),
+				mid:  hinter.ApCellRef(1),
+				high: hinter.ApCellRef(2),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			var low, mid, high big.Int
+			lowFelt := utils.ReadFrom(vm, VM.ExecutionSegment, 0)
+			midFelt := utils.ReadFrom(vm, VM.ExecutionSegment, 1)
+			highFelt := utils.ReadFrom(vm, VM.ExecutionSegment, 2)
+			lowFelt.Felt.BigInt(&low)
+			midFelt.Felt.BigInt(&mid)
+			highFelt.Felt.BigInt(&high)
+
+			require.LessOrEqual(t, low.BitLen(), 126)
+			require.LessOrEqual(t, mid.BitLen(), 126)
+			require.Equal(t, -1, high.Cmp(modulus))
+
+			// low + mid * 2**126 + high * 2**252 gives back the product
+			result := new(big.Int).Lsh(&high, 126)
+			result.Add(result, &mid)
+			result.Lsh(result, 126)
+			result.Add(result, &low)
+			require.Equal(t, new(big.Int).Mul(tc.a, tc.b), result)
+		})
+	}
+}
+
+func TestEcAdd(t *testing.T) {
+	feltFromHex := func(s string) f.Element {
+		felt, err := new(f.Element).SetString(s)
+		require.NoError(t, err)
+		return *felt
+	}
+
+	g := [2]f.Element{
+		feltFromHex("0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca"),
+		feltFromHex("0x5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f"),
+	}
+	g2 := [2]f.Element{
+		feltFromHex("0x759ca09377679ecd535a81e83039658bf40959283187c654c5416f439403cf5"),
+		feltFromHex("0x6f524a3400e7708d5c01a28598ad272e7455aa88778b19f93b562d7a9646c41"),
+	}
+	g3 := [2]f.Element{
+		feltFromHex("0x411494b501a98abd8262b0da1351e17899a0c4ef23dd2f96fec5ba847310b20"),
+		feltFromHex("0x7e1b3ebac08924d2c26f409549191fcf94f3bf6f301ed3553e22dfb802f0686"),
+	}
+	var negG [2]f.Element
+	negG[0] = g[0]
+	negG[1].Neg(&g[1])
+	infinity := [2]f.Element{}
+
+	testCases := []struct {
+		name        string
+		p           [2]f.Element
+		q           [2]f.Element
+		expected    [2]f.Element
+		expectedErr string
+	}{
+		{
+			name:     "TestEcAddDouble",
+			p:        g,
+			q:        g,
+			expected: g2,
+		},
+		{
+			name:     "TestEcAddDistinct",
+			p:        g,
+			q:        g2,
+			expected: g3,
+		},
+		{
+			name:     "TestEcAddInfinity",
+			p:        infinity,
+			q:        g2,
+			expected: g2,
+		},
+		{
+			name:     "TestEcAddOpposite",
+			p:        g,
+			q:        negG,
+			expected: infinity,
+		},
+		{
+			name:        "TestEcAddNotOnCurve",
+			p:           g,
+			q:           [2]f.Element{f.NewElement(1), f.NewElement(2)},
+			expectedErr: "point q(1, 2) is not on the curve",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			p := vm.Memory.AllocateEmptySegment()
+			q := vm.Memory.AllocateEmptySegment()
+			dst := vm.Memory.AllocateEmptySegment()
+			for i := 0; i < 2; i++ {
+				utils.WriteTo(vm, p.SegmentIndex, uint64(i), mem.MemoryValueFromFieldElement(&tc.p[i]))
+				utils.WriteTo(vm, q.SegmentIndex, uint64(i), mem.MemoryValueFromFieldElement(&tc.q[i]))
+			}
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&p))
+			utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&q))
+			utils.WriteTo(vm, VM.ExecutionSegment, 2, mem.MemoryValueFromMemoryAddress(&dst))
+
+			hint := EcAdd{
+				p:   hinter.Deref{Deref: hinter.ApCellRef(0)},
+				q:   hinter.Deref{Deref: hinter.ApCellRef(1)},
+				dst: hinter.Deref{Deref: hinter.ApCellRef(2)},
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			for i := 0; i < 2; i++ {
+				require.Equal(
+					t,
+					mem.MemoryValueFromFieldElement(&tc.expected[i]),
+					utils.ReadFrom(vm, dst.SegmentIndex, uint64(i)),
+				)
+			}
+		})
+	}
+}
+
+func TestAssertAccessDelta(t *testing.T) {
+	testCases := []struct {
+		name        string
+		delta       int64
+		expectedErr string
+	}{
+		{
+			name:  "TestAssertAccessDeltaValid",
+			delta: 4,
+		},
+		{
+			name:  "TestAssertAccessDeltaZero",
+			delta: 0,
+		},
+		{
+			name:        "TestAssertAccessDeltaNegative",
+			delta:       -3,
+			expectedErr: "access delta -3 is negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := AssertAccessDelta{
+				delta: hinter.Immediate(*new(f.Element).SetInt64(tc.delta)),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSlopeDenominatorInv(t *testing.T) {
+	testCases := []struct {
+		name        string
+		x1          uint64
+		x2          uint64
+		expectedErr string
+	}{
+		{
+			name: "TestSlopeDenominatorInvDistinct",
+			x1:   3,
+			x2:   10,
+		},
+		{
+			name: "TestSlopeDenominatorInvWrapping",
+			x1:   10,
+			x2:   3,
+		},
+		{
+			name:        "TestSlopeDenominatorInvVertical",
+			x1:          7,
+			x2:          7,
+			expectedErr: "x1 and x2 are both 7: the chord is a vertical line",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := SlopeDenominatorInv{
+				x1:  hinter.Immediate(f.NewElement(tc.x1)),
+				x2:  hinter.Immediate(f.NewElement(tc.x2)),
+				dst: hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			inv, err := vm.Memory.ReadAsElement(VM.ExecutionSegment, 0)
+			require.NoError(t, err)
+
+			x1 := f.NewElement(tc.x1)
+			x2 := f.NewElement(tc.x2)
+			var product f.Element
+			product.Sub(&x2, &x1)
+			product.Mul(&product, &inv)
+			require.True(t, product.IsOne())
+		})
+	}
+}
+
+func TestTangentSlope(t *testing.T) {
+	feltFromHex := func(s string) f.Element {
+		felt, err := new(f.Element).SetString(s)
+		require.NoError(t, err)
+		return *felt
+	}
+
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// generator point of the stark curve, where alpha = 1
+	hint := TangentSlope{
+		x:   hinter.Immediate(feltFromHex("0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca")),
+		y:   hinter.Immediate(feltFromHex("0x5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f")),
+		a:   hinter.Immediate(f.NewElement(1)),
+		dst: hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	expectedSlope := feltFromHex("0x3a2fcd28a5ba01b5ed8a1c16da45138f4df4fb7b845bbfcea5295bbfad92610")
+	require.Equal(
+		t,
+		mem.MemoryValueFromFieldElement(&expectedSlope),
+		utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+	)
+}
+
+func TestTangentSlopeVertical(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	hint := TangentSlope{
+		x:   hinter.Immediate(f.NewElement(5)),
+		y:   hinter.Immediate(f.NewElement(0)),
+		a:   hinter.Immediate(f.NewElement(1)),
+		dst: hinter.ApCellRef(0),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.EqualError(t, err, "y is zero: the tangent at (5, 0) is a vertical line")
+}
+
+func TestSplitIntoLimbs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       *big.Int
+		bitWidth    uint
+		n           uint
+		expected    []uint64
+		expectedErr string
+	}{
+		{
+			name:     "TestSplitIntoLimbsExactFit",
+			value:    new(big.Int).SetUint64(0xffff_ffff_ffff),
+			bitWidth: 16,
+			n:        3,
+			expected: []uint64{0xffff, 0xffff, 0xffff},
+		},
+		{
+			name:     "TestSplitIntoLimbsZeroHighLimb",
+			value:    new(big.Int).SetUint64(0x1234_5678),
+			bitWidth: 16,
+			n:        3,
+			expected: []uint64{0x5678, 0x1234, 0},
+		},
+		{
+			name:     "TestSplitIntoLimbsU256",
+			value:    new(big.Int).Lsh(big.NewInt(3), 128),
+			bitWidth: 128,
+			n:        2,
+			expected: []uint64{0, 3},
+		},
+		{
+			name:        "TestSplitIntoLimbsTooLarge",
+			value:       new(big.Int).SetUint64(1 << 48),
+			bitWidth:    16,
+			n:           3,
+			expectedErr: "value 281474976710656 does not fit in 3 limbs of 16 bits",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := SplitIntoLimbs{
+				value:    hinter.Immediate(*new(f.Element).SetBigInt(tc.value)),
+				bitWidth: tc.bitWidth,
+				n:        tc.n,
+				dst:      hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			for i, limb := range tc.expected {
+				require.Equal(
+					t,
+					mem.MemoryValueFromUint(limb),
+					utils.ReadFrom(vm, VM.ExecutionSegment, uint64(i)),
+				)
+			}
+		})
+	}
+}
+
+func TestEcAddFromSlope(t *testing.T) {
+	feltFromHex := func(s string) f.Element {
+		felt, err := new(f.Element).SetString(s)
+		require.NoError(t, err)
+		return *felt
+	}
+
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// G + 2G on the stark curve, using the chord slope between both points
+	hint := EcAddFromSlope{
+		slope: hinter.Immediate(feltFromHex("0x6cf0c2920ee397f0694583ed7f69354bb1d1da806bf5813ca714a251ad09c9a")),
+		x1:    hinter.Immediate(feltFromHex("0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca")),
+		y1:    hinter.Immediate(feltFromHex("0x5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f")),
+		x2:    hinter.Immediate(feltFromHex("0x759ca09377679ecd535a81e83039658bf40959283187c654c5416f439403cf5")),
+		x3Dst: hinter.ApCellRef(0),
+		y3Dst: hinter.ApCellRef(1),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	expectedX3 := feltFromHex("0x411494b501a98abd8262b0da1351e17899a0c4ef23dd2f96fec5ba847310b20")
+	expectedY3 := feltFromHex("0x7e1b3ebac08924d2c26f409549191fcf94f3bf6f301ed3553e22dfb802f0686")
+	require.Equal(t, mem.MemoryValueFromFieldElement(&expectedX3), utils.ReadFrom(vm, VM.ExecutionSegment, 0))
+	require.Equal(t, mem.MemoryValueFromFieldElement(&expectedY3), utils.ReadFrom(vm, VM.ExecutionSegment, 1))
+}
+
+func TestBuiltinUsageSummary(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	output := vm.Memory.AllocateBuiltinSegment(&builtins.Output{})
+	rangeCheck := vm.Memory.AllocateBuiltinSegment(&builtins.RangeCheck{})
+	dst := vm.Memory.AllocateEmptySegment()
+
+	for i := 0; i < 3; i++ {
+		utils.WriteTo(vm, output.SegmentIndex, uint64(i), mem.MemoryValueFromInt(i))
+	}
+	// a hole before the last written cell still counts as used
+	utils.WriteTo(vm, rangeCheck.SegmentIndex, 0, mem.MemoryValueFromInt(1))
+	utils.WriteTo(vm, rangeCheck.SegmentIndex, 4, mem.MemoryValueFromInt(2))
+
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&dst))
+
+	hint := BuiltinUsageSummary{
+		dst: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, mem.MemoryValueFromInt(3), utils.ReadFrom(vm, dst.SegmentIndex, 0))
+	require.Equal(t, mem.MemoryValueFromInt(5), utils.ReadFrom(vm, dst.SegmentIndex, 1))
+	require.Equal(t, uint64(2), vm.Memory.Segments[dst.SegmentIndex].Len())
+}
+
+func TestAssertRcDecomposition(t *testing.T) {
+	testCases := []struct {
+		name        string
+		limbs       []uint64
+		value       uint64
+		expectedErr string
+	}{
+		{
+			name:  "TestAssertRcDecompositionValid",
+			limbs: []uint64{0x56, 0x34, 0x12},
+			value: 0x123456,
+		},
+		{
+			name:        "TestAssertRcDecompositionTampered",
+			limbs:       []uint64{0x56, 0x35, 0x12},
+			value:       0x123456,
+			expectedErr: "limbs recompose to 1193302, expected 1193046",
+		},
+		{
+			name:        "TestAssertRcDecompositionLimbOutOfRange",
+			limbs:       []uint64{0x156, 0x33, 0x12},
+			value:       0x123456,
+			expectedErr: "limb 0: 342 is not smaller than base 256",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			limbs := vm.Memory.AllocateEmptySegment()
+			for i, limb := range tc.limbs {
+				utils.WriteTo(vm, limbs.SegmentIndex, uint64(i), mem.MemoryValueFromUint(limb))
+			}
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&limbs))
+
+			hint := AssertRcDecomposition{
+				ptr:   hinter.Deref{Deref: hinter.ApCellRef(0)},
+				len:   hinter.Immediate(f.NewElement(uint64(len(tc.limbs)))),
+				base:  hinter.Immediate(f.NewElement(256)),
+				value: hinter.Immediate(f.NewElement(tc.value)),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAssertRcDecompositionLengthTooLarge(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	limbs := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&limbs))
+
+	hint := AssertRcDecomposition{
+		ptr:   hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len:   hinter.Immediate(f.NewElement(1 << 40)),
+		base:  hinter.Immediate(f.NewElement(256)),
+		value: hinter.Immediate(f.NewElement(0)),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestIsQuadraticResidue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    uint64
+		expected int
+	}{
+		{
+			name:     "TestIsQuadraticResidue",
+			value:    49,
+			expected: 1,
+		},
+		{
+			name:     "TestIsQuadraticResidueNonResidue",
+			value:    27,
+			expected: 0,
+		},
+		{
+			name:     "TestIsQuadraticResidueZero",
+			value:    0,
+			expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := IsQuadraticResidue{
+				value: hinter.Immediate(f.NewElement(tc.value)),
+				dst:   hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromInt(tc.expected),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+			)
+		})
+	}
+}
+
+func TestPoseidonHashChain(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []uint64
+		expected string
+	}{
+		{
+			name:     "TestPoseidonHashChainEmpty",
+			values:   []uint64{},
+			expected: "0x2272be0f580fd156823304800919530eaa97430e972d7213ee13f4fbf7a5dbc",
+		},
+		{
+			name:     "TestPoseidonHashChainEven",
+			values:   []uint64{1, 2},
+			expected: "0x371cb6995ea5e7effcd2e174de264b5b407027a75a231a70c2c8d196107f0e7",
+		},
+		{
+			name:     "TestPoseidonHashChainOdd",
+			values:   []uint64{1, 2, 3},
+			expected: "0x2f0d8840bcf3bc629598d8a6cc80cb7c0d9e52d93dab244bbf9cd0dca0ad082",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			input := vm.Memory.AllocateEmptySegment()
+			for i, value := range tc.values {
+				utils.WriteTo(vm, input.SegmentIndex, uint64(i), mem.MemoryValueFromUint(value))
+			}
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&input))
+
+			hint := PoseidonHashChain{
+				ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+				len: hinter.Immediate(f.NewElement(uint64(len(tc.values)))),
+				dst: hinter.ApCellRef(1),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			expected, err := new(f.Element).SetString(tc.expected)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromFieldElement(expected),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 1),
+			)
+		})
+	}
+}
+
+func TestPoseidonHashChainLengthTooLarge(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	values := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&values))
+
+	hint := PoseidonHashChain{
+		ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len: hinter.Immediate(f.NewElement(1 << 40)),
+		dst: hinter.ApCellRef(1),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestModExp(t *testing.T) {
+	testCases := []struct {
+		name        string
+		base        uint64
+		exponent    uint64
+		modulus     uint64
+		expected    uint64
+		expectedErr string
+	}{
+		{
+			name:     "TestModExpZeroExponent",
+			base:     12345,
+			exponent: 0,
+			modulus:  97,
+			expected: 1,
+		},
+		{
+			name:     "TestModExpModulusOne",
+			base:     12345,
+			exponent: 7,
+			modulus:  1,
+			expected: 0,
+		},
+		{
+			name:     "TestModExp",
+			base:     4,
+			exponent: 13,
+			modulus:  497,
+			expected: 445,
+		},
+		{
+			name:        "TestModExpZeroModulus",
+			base:        4,
+			exponent:    13,
+			modulus:     0,
+			expectedErr: "modulus is zero",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := ModExp{
+				base:     hinter.Immediate(f.NewElement(tc.base)),
+				exponent: hinter.Immediate(f.NewElement(tc.exponent)),
+				modulus:  hinter.Immediate(f.NewElement(tc.modulus)),
+				dst:      hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromUint(tc.expected),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+			)
+		})
+	}
+}
+
+func TestPedersenHashArray(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []string
+		expected string
+	}{
+		{
+			name:     "TestPedersenHashArrayEmpty",
+			values:   []string{},
+			expected: "0x49ee3eba8c1600700ee1b87eb599f16716b0b1022947733551fde4050ca6804",
+		},
+		{
+			// contract address computation, hashing
+			// [prefix, caller_address, salt, class_hash, calldata_hash]
+			name: "TestPedersenHashArrayContractAddress",
+			values: []string{
+				"0x535441524b4e45545f434f4e54524143545f41444452455353",
+				"0x0",
+				"0x5bebda1b28ba6daa824126577b9fbc984033e8b18360f5e1ef694cb172c7aa5",
+				"0x0439218681f9108b470d2379cf589ef47e60dc5888ee49ec70071671d74ca9c6",
+				"0x49ee3eba8c1600700ee1b87eb599f16716b0b1022947733551fde4050ca6804",
+			},
+			expected: "0x43c6817e70b3fd99a4f120790b2e82c6843df62b573fdadf9e2d677b60ac5eb",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			input := vm.Memory.AllocateEmptySegment()
+			for i, value := range tc.values {
+				felt, err := new(f.Element).SetString(value)
+				require.NoError(t, err)
+				utils.WriteTo(vm, input.SegmentIndex, uint64(i), mem.MemoryValueFromFieldElement(felt))
+			}
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&input))
+
+			hint := PedersenHashArray{
+				ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+				len: hinter.Immediate(f.NewElement(uint64(len(tc.values)))),
+				dst: hinter.ApCellRef(1),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			expected, err := new(f.Element).SetString(tc.expected)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromFieldElement(expected),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 1),
+			)
+		})
+	}
+}
+
+func TestPedersenHashArrayLengthTooLarge(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	values := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&values))
+
+	hint := PedersenHashArray{
+		ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len: hinter.Immediate(f.NewElement(1 << 40)),
+		dst: hinter.ApCellRef(1),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestAssertRangeInitialized(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	rangeSegment := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, rangeSegment.SegmentIndex, 0, mem.MemoryValueFromInt(1))
+	utils.WriteTo(vm, rangeSegment.SegmentIndex, 1, mem.MemoryValueFromInt(2))
+	utils.WriteTo(vm, rangeSegment.SegmentIndex, 3, mem.MemoryValueFromInt(4))
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&rangeSegment))
+
+	hint := AssertRangeInitialized{
+		ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len: hinter.Immediate(f.NewElement(2)),
+	}
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	hint.len = hinter.Immediate(f.NewElement(4))
+	err = hint.Execute(vm, nil)
+	require.EqualError(t, err, "cell 2:2 at index 2 of the range is uninitialized")
+}
+
+func TestMaterializeBuiltinOutputs(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// Add-mod circuit over p = 2^96 + 1 with x1 = 17 at offset 0 and x2 = 23 at
+	// offset 4, computing r1 = x1 + x2 at offset 8 and r2 = r1 + x1 at offset 12
+	values := vm.Memory.AllocateEmptySegment()
+	for i, value := range []int{17, 0, 0, 0, 23, 0, 0, 0} {
+		utils.WriteTo(vm, values.SegmentIndex, uint64(i), mem.MemoryValueFromInt(value))
+	}
+	offsets := vm.Memory.AllocateEmptySegment()
+	for i, offset := range []int{0, 4, 8, 8, 0, 12} {
+		utils.WriteTo(vm, offsets.SegmentIndex, uint64(i), mem.MemoryValueFromInt(offset))
+	}
+
+	// a batch size of 1 gives one builtin instance per operation, and only the
+	// first one is written by the program
+	addMod := vm.Memory.AllocateBuiltinSegment(builtins.NewModBuiltin(1, 96, 1, builtins.Add))
+	for i, word := range []int{1, 1, 0, 0} {
+		utils.WriteTo(vm, addMod.SegmentIndex, uint64(i), mem.MemoryValueFromInt(word))
+	}
+	utils.WriteTo(vm, addMod.SegmentIndex, 4, mem.MemoryValueFromMemoryAddress(&values))
+	utils.WriteTo(vm, addMod.SegmentIndex, 5, mem.MemoryValueFromMemoryAddress(&offsets))
+	utils.WriteTo(vm, addMod.SegmentIndex, 6, mem.MemoryValueFromInt(2))
+
+	dst := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&addMod))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&dst))
+
+	secondN := mem.MemoryAddress{SegmentIndex: addMod.SegmentIndex, Offset: 13}
+	require.False(t, vm.Memory.KnownValueAtAddress(&secondN))
+
+	hint := MaterializeBuiltinOutputs{
+		builtinPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		n:          hinter.Immediate(f.NewElement(14)),
+		dstPtr:     hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+	require.NoError(t, hint.Execute(vm, nil))
+
+	// the second instance was deduced and copied along with the first one
+	secondOffsets, err := offsets.AddOffset(3)
+	require.NoError(t, err)
+	expected := []mem.MemoryValue{
+		mem.MemoryValueFromInt(1),
+		mem.MemoryValueFromInt(1),
+		mem.MemoryValueFromInt(0),
+		mem.MemoryValueFromInt(0),
+		mem.MemoryValueFromMemoryAddress(&values),
+		mem.MemoryValueFromMemoryAddress(&offsets),
+		mem.MemoryValueFromInt(2),
+		mem.MemoryValueFromInt(1),
+		mem.MemoryValueFromInt(1),
+		mem.MemoryValueFromInt(0),
+		mem.MemoryValueFromInt(0),
+		mem.MemoryValueFromMemoryAddress(&values),
+		mem.MemoryValueFromMemoryAddress(&secondOffsets),
+		mem.MemoryValueFromInt(1),
+	}
+	for i, value := range expected {
+		require.Equal(t, value, utils.ReadFrom(vm, dst.SegmentIndex, uint64(i)), "cell %d", i)
+	}
+
+	// the operations themselves were evaluated
+	require.Equal(t, mem.MemoryValueFromInt(40), utils.ReadFrom(vm, values.SegmentIndex, 8))
+	require.Equal(t, mem.MemoryValueFromInt(57), utils.ReadFrom(vm, values.SegmentIndex, 12))
+}
+
+func TestMaterializeBuiltinOutputsMulMod(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// Mul-mod circuit over p = 2^96 + 1 computing r = x1 * x2 at offset 8, with
+	// x1 = 17 at offset 0 and x2 = 23 at offset 4
+	values := vm.Memory.AllocateEmptySegment()
+	for i, value := range []int{17, 0, 0, 0, 23, 0, 0, 0} {
+		utils.WriteTo(vm, values.SegmentIndex, uint64(i), mem.MemoryValueFromInt(value))
+	}
+	offsets := vm.Memory.AllocateEmptySegment()
+	for i, offset := range []int{0, 4, 8} {
+		utils.WriteTo(vm, offsets.SegmentIndex, uint64(i), mem.MemoryValueFromInt(offset))
+	}
+
+	mulMod := vm.Memory.AllocateBuiltinSegment(builtins.NewModBuiltin(1, 96, 1, builtins.Mul))
+	for i, word := range []int{1, 1, 0, 0} {
+		utils.WriteTo(vm, mulMod.SegmentIndex, uint64(i), mem.MemoryValueFromInt(word))
+	}
+	utils.WriteTo(vm, mulMod.SegmentIndex, 4, mem.MemoryValueFromMemoryAddress(&values))
+	utils.WriteTo(vm, mulMod.SegmentIndex, 5, mem.MemoryValueFromMemoryAddress(&offsets))
+	utils.WriteTo(vm, mulMod.SegmentIndex, 6, mem.MemoryValueFromInt(1))
+
+	dst := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&mulMod))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&dst))
+
+	hint := MaterializeBuiltinOutputs{
+		builtinPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		n:          hinter.Immediate(f.NewElement(7)),
+		dstPtr:     hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+	require.NoError(t, hint.Execute(vm, nil))
+
+	// the operation was evaluated as a multiplication
+	require.Equal(t, mem.MemoryValueFromInt(391), utils.ReadFrom(vm, values.SegmentIndex, 8))
+	require.Equal(t, mem.MemoryValueFromInt(1), utils.ReadFrom(vm, dst.SegmentIndex, 6))
+}
+
+func TestMaterializeBuiltinOutputsLengthTooLarge(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	pedersen := vm.Memory.AllocateBuiltinSegment(&builtins.Pedersen{})
+	dst := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&pedersen))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&dst))
+
+	hint := MaterializeBuiltinOutputs{
+		builtinPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		n:          hinter.Immediate(f.NewElement(1 << 40)),
+		dstPtr:     hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestCountLeadingZeros(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    *big.Int
+		expected int
+	}{
+		{
+			name:     "TestCountLeadingZerosZero",
+			value:    big.NewInt(0),
+			expected: 252,
+		},
+		{
+			name:     "TestCountLeadingZerosOne",
+			value:    big.NewInt(1),
+			expected: 251,
+		},
+		{
+			name:     "TestCountLeadingZerosTopBit",
+			value:    new(big.Int).Lsh(big.NewInt(1), 251),
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := CountLeadingZeros{
+				value: hinter.Immediate(*new(f.Element).SetBigInt(tc.value)),
+				dst:   hinter.ApCellRef(0),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromInt(tc.expected),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 0),
+			)
+		})
+	}
+}
+
+func TestBatchInvToScope(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+	ctx := hinter.InitializeDefaultContext()
+
+	values := []uint64{2, 5, 11}
+	src := vm.Memory.AllocateEmptySegment()
+	for i, value := range values {
+		utils.WriteTo(vm, src.SegmentIndex, uint64(i), mem.MemoryValueFromUint(value))
+	}
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&src))
+
+	hint := BatchInvToScope{
+		ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len: hinter.Immediate(f.NewElement(uint64(len(values)))),
+	}
+
+	err := hint.Execute(vm, ctx)
+	require.NoError(t, err)
+
+	inverses, err := hinter.GetVariableAs[[]*f.Element](&ctx.ScopeManager, "inverses")
+	require.NoError(t, err)
+	require.Len(t, inverses, len(values))
+	for i, value := range values {
+		expected := f.NewElement(value)
+		expected.Inverse(&expected)
+		require.Equal(t, &expected, inverses[i])
+	}
+}
+
+func TestBatchInvToScopeZero(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+	ctx := hinter.InitializeDefaultContext()
+
+	src := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, src.SegmentIndex, 0, mem.MemoryValueFromInt(3))
+	utils.WriteTo(vm, src.SegmentIndex, 1, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&src))
+
+	hint := BatchInvToScope{
+		ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len: hinter.Immediate(f.NewElement(2)),
+	}
+
+	err := hint.Execute(vm, ctx)
+	require.EqualError(t, err, "element 1 is zero and has no inverse")
+}
+
+func TestBatchInvToScopeLengthTooLarge(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+	ctx := hinter.InitializeDefaultContext()
+
+	src := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&src))
+
+	hint := BatchInvToScope{
+		ptr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len: hinter.Immediate(f.NewElement(1 << 40)),
+	}
+
+	err := hint.Execute(vm, ctx)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestMerkleRoot(t *testing.T) {
+	testCases := []struct {
+		name     string
+		leaves   []uint64
+		hashKind HashKind
+		expected string
+	}{
+		{
+			// pedersen(pedersen(1, 2), pedersen(3, 4))
+			name:     "TestMerkleRootPedersen",
+			leaves:   []uint64{1, 2, 3, 4},
+			hashKind: PedersenHashKind,
+			expected: "0x6a27df2b1eaf16c77478b9c001cfdebe956b7ad878b141b0b4b24659fa59fde",
+		},
+		{
+			// poseidon(poseidon(1, 2), poseidon(3, 4))
+			name:     "TestMerkleRootPoseidon",
+			leaves:   []uint64{1, 2, 3, 4},
+			hashKind: PoseidonHashKind,
+			expected: "0x37c93a8507ea3cf33567ae2c6c33a0d86b997edcfc3b87280d9a572b2cde39b",
+		},
+		{
+			// padded to 1, 2, 3, 0
+			name:     "TestMerkleRootPadding",
+			leaves:   []uint64{1, 2, 3},
+			hashKind: PedersenHashKind,
+			expected: "0x4830fe2e47fca6dd87be35c6c4c0be2519aa0ef2af2e5751fc1934dfa536705",
+		},
+		{
+			name:     "TestMerkleRootSingleLeaf",
+			leaves:   []uint64{7},
+			hashKind: PoseidonHashKind,
+			expected: "0x7",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			leaves := vm.Memory.AllocateEmptySegment()
+			for i, leaf := range tc.leaves {
+				utils.WriteTo(vm, leaves.SegmentIndex, uint64(i), mem.MemoryValueFromUint(leaf))
+			}
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&leaves))
+
+			hint := MerkleRoot{
+				leavesPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+				n:         hinter.Immediate(f.NewElement(uint64(len(tc.leaves)))),
+				dst:       hinter.ApCellRef(1),
+				hashKind:  tc.hashKind,
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			expected, err := new(f.Element).SetString(tc.expected)
+			require.NoError(t, err)
+			require.Equal(
+				t,
+				mem.MemoryValueFromFieldElement(expected),
+				utils.ReadFrom(vm, VM.ExecutionSegment, 1),
+			)
+		})
+	}
+}
+
+func TestMerkleRootTooManyLeaves(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	leaves := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&leaves))
+
+	hint := MerkleRoot{
+		leavesPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		n:         hinter.Immediate(f.NewElement(1 << 40)),
+		dst:       hinter.ApCellRef(1),
+		hashKind:  PoseidonHashKind,
+	}
+
+	err := hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestU256Shift(t *testing.T) {
+	testCases := []struct {
+		name         string
+		left         bool
+		low          string
+		high         string
+		shift        uint64
+		expectedLow  string
+		expectedHigh string
+	}{
+		{
+			name:         "TestU256ShlWithinLimb",
+			left:         true,
+			low:          "0x1",
+			high:         "0x0",
+			shift:        4,
+			expectedLow:  "0x10",
+			expectedHigh: "0x0",
+		},
+		{
+			name:         "TestU256ShlAcrossLimbs",
+			left:         true,
+			low:          "0x80000000000000000000000000000001",
+			high:         "0x3",
+			shift:        1,
+			expectedLow:  "0x2",
+			expectedHigh: "0x7",
+		},
+		{
+			name:         "TestU256ShlDropsOverflow",
+			left:         true,
+			low:          "0x0",
+			high:         "0x80000000000000000000000000000005",
+			shift:        1,
+			expectedLow:  "0x0",
+			expectedHigh: "0xa",
+		},
+		{
+			name:         "TestU256Shl256",
+			left:         true,
+			low:          "0x1",
+			high:         "0x1",
+			shift:        256,
+			expectedLow:  "0x0",
+			expectedHigh: "0x0",
+		},
+		{
+			name:         "TestU256ShrWithinLimb",
+			low:          "0x100",
+			high:         "0x0",
+			shift:        4,
+			expectedLow:  "0x10",
+			expectedHigh: "0x0",
+		},
+		{
+			name:         "TestU256ShrAcrossLimbs",
+			low:          "0x0",
+			high:         "0x3",
+			shift:        1,
+			expectedLow:  "0x80000000000000000000000000000000",
+			expectedHigh: "0x1",
+		},
+		{
+			name:         "TestU256Shr256",
+			low:          "0x1",
+			high:         "0x1",
+			shift:        256,
+			expectedLow:  "0x0",
+			expectedHigh: "0x0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			low, err := new(f.Element).SetString(tc.low)
+			require.NoError(t, err)
+			high, err := new(f.Element).SetString(tc.high)
+			require.NoError(t, err)
+
+			var hint hinter.Hinter
+			if tc.left {
+				hint = &U256Shl{
+					low:     hinter.Immediate(*low),
+					high:    hinter.Immediate(*high),
+					shift:   hinter.Immediate(f.NewElement(tc.shift)),
+					dstLow:  hinter.ApCellRef(0),
+					dstHigh: hinter.ApCellRef(1),
+				}
+			} else {
+				hint = &U256Shr{
+					low:     hinter.Immediate(*low),
+					high:    hinter.Immediate(*high),
+					shift:   hinter.Immediate(f.NewElement(tc.shift)),
+					dstLow:  hinter.ApCellRef(0),
+					dstHigh: hinter.ApCellRef(1),
+				}
+			}
+
+			err = hint.Execute(vm, nil)
+			require.NoError(t, err)
+
+			expectedLow, err := new(f.Element).SetString(tc.expectedLow)
+			require.NoError(t, err)
+			expectedHigh, err := new(f.Element).SetString(tc.expectedHigh)
+			require.NoError(t, err)
+			require.Equal(t, mem.MemoryValueFromFieldElement(expectedLow), utils.ReadFrom(vm, VM.ExecutionSegment, 0))
+			require.Equal(t, mem.MemoryValueFromFieldElement(expectedHigh), utils.ReadFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestU256ShiftLimbOutOfRange(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// 2**128
+	high, err := new(f.Element).SetString("0x100000000000000000000000000000000")
+	require.NoError(t, err)
+
+	hint := U256Shl{
+		low:     hinter.Immediate(f.NewElement(0)),
+		high:    hinter.Immediate(*high),
+		shift:   hinter.Immediate(f.NewElement(1)),
+		dstLow:  hinter.ApCellRef(0),
+		dstHigh: hinter.ApCellRef(1),
+	}
+
+	err = hint.Execute(vm, nil)
+	require.EqualError(t, err, "high limb: 340282366920938463463374607431768211456 should be u128")
+}
+
+func TestRationalReconstruct(t *testing.T) {
+	one := f.NewElement(1)
+	two := f.NewElement(2)
+	three := f.NewElement(3)
+	seven := f.NewElement(7)
+	minusTwo := new(f.Element).Neg(&two)
+
+	testCases := []struct {
+		name        string
+		value       f.Element
+		expectedNum f.Element
+		expectedDen f.Element
+	}{
+		{
+			name:        "TestRationalReconstructOneThird",
+			value:       *new(f.Element).Inverse(&three),
+			expectedNum: one,
+			expectedDen: three,
+		},
+		{
+			name:        "TestRationalReconstructNegative",
+			value:       *new(f.Element).Div(minusTwo, &seven),
+			expectedNum: *minusTwo,
+			expectedDen: seven,
+		},
+		{
+			name:        "TestRationalReconstructInteger",
+			value:       f.NewElement(5),
+			expectedNum: f.NewElement(5),
+			expectedDen: one,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := RationalReconstruct{
+				value:  hinter.Immediate(tc.value),
+				numDst: hinter.ApCellRef(0),
+				denDst: hinter.ApCellRef(1),
+			}
+
+			err := hint.Execute(vm, nil)
+			require.NoError(t, err)
+			require.Equal(t, mem.MemoryValueFromFieldElement(&tc.expectedNum), utils.ReadFrom(vm, VM.ExecutionSegment, 0))
+			require.Equal(t, mem.MemoryValueFromFieldElement(&tc.expectedDen), utils.ReadFrom(vm, VM.ExecutionSegment, 1))
+		})
+	}
+}
+
+func TestRationalReconstructNotFound(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// 3**150 has no representation as a fraction with small terms
+	value, err := new(f.Element).SetString("0x359ba2b98ca11d6864a331b45ae7114c01ffbdcf60cc16e692fb63c6e219")
+	require.NoError(t, err)
+
+	hint := RationalReconstruct{
+		value:  hinter.Immediate(*value),
+		numDst: hinter.ApCellRef(0),
+		denDst: hinter.ApCellRef(1),
+	}
+
+	err = hint.Execute(vm, nil)
+	require.ErrorContains(t, err, "is not a fraction with numerator and denominator bounded by")
+}
+
+func TestAssertStride(t *testing.T) {
+	testCases := []struct {
+		name          string
+		prev          mem.MemoryAddress
+		cur           mem.MemoryAddress
+		errorExpected string
+	}{
+		{
+			name: "TestAssertStrideCorrect",
+			prev: mem.MemoryAddress{SegmentIndex: 2, Offset: 6},
+			cur:  mem.MemoryAddress{SegmentIndex: 2, Offset: 9},
+		},
+		{
+			name:          "TestAssertStrideOffByOne",
+			prev:          mem.MemoryAddress{SegmentIndex: 2, Offset: 6},
+			cur:           mem.MemoryAddress{SegmentIndex: 2, Offset: 10},
+			errorExpected: "pointer advanced from 2:6 to 2:10, expected a stride of 3",
+		},
+		{
+			name:          "TestAssertStrideDifferentSegments",
+			prev:          mem.MemoryAddress{SegmentIndex: 2, Offset: 6},
+			cur:           mem.MemoryAddress{SegmentIndex: 3, Offset: 9},
+			errorExpected: "pointers 2:6 and 3:9 are in different segments",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&tc.prev))
+			utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&tc.cur))
+
+			hint := AssertStride{
+				prevPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+				curPtr:  hinter.Deref{Deref: hinter.ApCellRef(1)},
+				stride:  hinter.Immediate(f.NewElement(3)),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.errorExpected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.errorExpected)
+			}
+		})
+	}
+}
+
+func TestUint384DivMod(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// dividend = UInt384(10, 3, 7, 1)
+	// divisor = UInt384(1, 1, 0, 0) = 2**96 + 1, the modulus of the EvalCircuit tests
+	hint := Uint384DivMod{
+		dividend: [4]hinter.Reference{
+			hinter.Immediate(f.NewElement(10)),
+			hinter.Immediate(f.NewElement(3)),
+			hinter.Immediate(f.NewElement(7)),
+			hinter.Immediate(f.NewElement(1)),
+		},
+		divisor: [4]hinter.Reference{
+			hinter.Immediate(f.NewElement(1)),
+			hinter.Immediate(f.NewElement(1)),
+			hinter.Immediate(f.NewElement(0)),
+			hinter.Immediate(f.NewElement(0)),
+		},
+		quotient:  [4]hinter.Reference{hinter.ApCellRef(0), hinter.ApCellRef(1), hinter.ApCellRef(2), hinter.ApCellRef(3)},
+		remainder: [4]hinter.Reference{hinter.ApCellRef(4), hinter.ApCellRef(5), hinter.ApCellRef(6), hinter.ApCellRef(7)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.NoError(t, err)
+
+	// 2**96 - 3
+	quotient0, err := new(f.Element).SetString("79228162514264337593543950333")
+	require.NoError(t, err)
+
+	expected := []mem.MemoryValue{
+		mem.MemoryValueFromFieldElement(quotient0),
+		mem.MemoryValueFromInt(5),
+		mem.MemoryValueFromInt(1),
+		mem.MemoryValueFromInt(0),
+		mem.MemoryValueFromInt(13),
+		mem.MemoryValueFromInt(0),
+		mem.MemoryValueFromInt(0),
+		mem.MemoryValueFromInt(0),
+	}
+	for i, value := range expected {
+		require.Equal(t, value, utils.ReadFrom(vm, VM.ExecutionSegment, uint64(i)), "cell %d", i)
+	}
+}
+
+func TestUint384DivModDivisionByZero(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	zero := hinter.Immediate(f.NewElement(0))
+	hint := Uint384DivMod{
+		dividend:  [4]hinter.Reference{hinter.Immediate(f.NewElement(138)), zero, zero, zero},
+		divisor:   [4]hinter.Reference{zero, zero, zero, zero},
+		quotient:  [4]hinter.Reference{hinter.ApCellRef(0), hinter.ApCellRef(1), hinter.ApCellRef(2), hinter.ApCellRef(3)},
+		remainder: [4]hinter.Reference{hinter.ApCellRef(4), hinter.ApCellRef(5), hinter.ApCellRef(6), hinter.ApCellRef(7)},
+	}
+
+	err := hint.Execute(vm, nil)
+	require.EqualError(t, err, "cannot divide 138 by a zero divisor")
+}
+
+func TestUint384DivModLimbOutOfRange(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// 2**96
+	tooBig, err := new(f.Element).SetString("0x1000000000000000000000000")
+	require.NoError(t, err)
+
+	zero := hinter.Immediate(f.NewElement(0))
+	hint := Uint384DivMod{
+		dividend:  [4]hinter.Reference{zero, hinter.Immediate(*tooBig), zero, zero},
+		divisor:   [4]hinter.Reference{hinter.Immediate(f.NewElement(1)), zero, zero, zero},
+		quotient:  [4]hinter.Reference{hinter.ApCellRef(0), hinter.ApCellRef(1), hinter.ApCellRef(2), hinter.ApCellRef(3)},
+		remainder: [4]hinter.Reference{hinter.ApCellRef(4), hinter.ApCellRef(5), hinter.ApCellRef(6), hinter.ApCellRef(7)},
+	}
+
+	err = hint.Execute(vm, nil)
+	require.EqualError(t, err, "dividend limb 1: 79228162514264337593543950336 does not fit in 96 bits")
+}
+
+func TestBloomFilter(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	filter := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&filter))
+
+	// 5 sets the bits 59, 61 and 11, 42 sets the bits 21, 4 and 27
+	for _, value := range []uint64{5, 42} {
+		insert := BloomInsert{
+			filterPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+			size:      hinter.Immediate(f.NewElement(64)),
+			value:     hinter.Immediate(f.NewElement(value)),
+		}
+		require.NoError(t, insert.Execute(vm, nil))
+	}
+
+	// inserting a value again leaves the filter unchanged
+	insert := BloomInsert{
+		filterPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		size:      hinter.Immediate(f.NewElement(64)),
+		value:     hinter.Immediate(f.NewElement(5)),
+	}
+	require.NoError(t, insert.Execute(vm, nil))
+
+	testCases := []struct {
+		value    uint64
+		expected int
+	}{
+		{value: 5, expected: 1},
+		{value: 42, expected: 1},
+		// 100 maps to the bits 4, 11 and 12, only the last one is unset
+		{value: 100, expected: 0},
+		{value: 7, expected: 0},
+	}
+
+	for i, tc := range testCases {
+		query := BloomQuery{
+			filterPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+			size:      hinter.Immediate(f.NewElement(64)),
+			value:     hinter.Immediate(f.NewElement(tc.value)),
+			dst:       hinter.ApCellRef(int16(i + 1)),
+		}
+		require.NoError(t, query.Execute(vm, nil))
+		require.Equal(t, mem.MemoryValueFromInt(tc.expected), utils.ReadFrom(vm, VM.ExecutionSegment, uint64(i+1)), "value %d", tc.value)
+	}
+
+	for _, bit := range []uint64{4, 11, 21, 27, 59, 61} {
+		require.Equal(t, mem.MemoryValueFromInt(1), utils.ReadFrom(vm, filter.SegmentIndex, bit))
+	}
+}
+
+func TestBloomQueryZeroedFilter(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	alloc := AllocZeroedSegment{
+		Size: hinter.Immediate(f.NewElement(64)),
+		Dst:  hinter.ApCellRef(0),
+	}
+	require.NoError(t, alloc.Execute(vm, nil))
+
+	// the bits of 5 hold zero rather than 1, so they do not count as set
+	query := BloomQuery{
+		filterPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		size:      hinter.Immediate(f.NewElement(64)),
+		value:     hinter.Immediate(f.NewElement(5)),
+		dst:       hinter.ApCellRef(1),
+	}
+	require.NoError(t, query.Execute(vm, nil))
+	require.Equal(t, mem.MemoryValueFromInt(0), utils.ReadFrom(vm, VM.ExecutionSegment, 1))
+}
+
+func TestBloomInvalidFilterSize(t *testing.T) {
+	for _, size := range []uint64{0, 1 << 40} {
+		vm := VM.DefaultVirtualMachine()
+		vm.Context.Ap = 0
+		vm.Context.Fp = 0
+
+		filter := vm.Memory.AllocateEmptySegment()
+		utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&filter))
+
+		insert := BloomInsert{
+			filterPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+			size:      hinter.Immediate(f.NewElement(size)),
+			value:     hinter.Immediate(f.NewElement(5)),
+		}
+		require.Error(t, insert.Execute(vm, nil), "size %d", size)
+		require.Equal(t, uint64(0), vm.Memory.Segments[filter.SegmentIndex].Len(), "size %d", size)
+	}
+}
+
+func TestAssertCircuitOutput(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// Add-mod circuit over p = 2^96 + 1 computing res = x1 + x2 with
+	// x1 = UInt384(17,0,0,0) at offset 0, x2 = UInt384(23,0,0,0) at offset 4
+	// and res at offset 8
+	values := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, values.SegmentIndex, 0, mem.MemoryValueFromInt(17))
+	utils.WriteTo(vm, values.SegmentIndex, 1, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, values.SegmentIndex, 2, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, values.SegmentIndex, 3, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, values.SegmentIndex, 4, mem.MemoryValueFromInt(23))
+	utils.WriteTo(vm, values.SegmentIndex, 5, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, values.SegmentIndex, 6, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, values.SegmentIndex, 7, mem.MemoryValueFromInt(0))
+
+	offsets := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, offsets.SegmentIndex, 0, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, offsets.SegmentIndex, 1, mem.MemoryValueFromInt(4))
+	utils.WriteTo(vm, offsets.SegmentIndex, 2, mem.MemoryValueFromInt(8))
+
+	AddModBuiltin := vm.Memory.AllocateBuiltinSegment(builtins.NewModBuiltin(1, 96, 1, builtins.Add))
+	MulModBuiltin := vm.Memory.AllocateBuiltinSegment(builtins.NewModBuiltin(1, 96, 1, builtins.Mul))
+
+	// p = UInt384(1,1,0,0)
+	utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 0, mem.MemoryValueFromInt(1))
+	utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 1, mem.MemoryValueFromInt(1))
+	utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 2, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 3, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 4, mem.MemoryValueFromMemoryAddress(&values))
+	utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 5, mem.MemoryValueFromMemoryAddress(&offsets))
+	utils.WriteTo(vm, AddModBuiltin.SegmentIndex, 6, mem.MemoryValueFromInt(1))
+
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&AddModBuiltin))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&MulModBuiltin))
+	utils.WriteTo(vm, VM.ExecutionSegment, 2, mem.MemoryValueFromMemoryAddress(&values))
+
+	evalCircuit := EvalCircuit{
+		AddModN:   hinter.Immediate(f.NewElement(1)),
+		AddModPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		MulModN:   hinter.Immediate(f.NewElement(0)),
+		MulModPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+	require.NoError(t, evalCircuit.Execute(vm, nil))
+
+	// 2^96 + 1
+	modulus, err := new(f.Element).SetString("79228162514264337593543950337")
+	require.NoError(t, err)
+
+	// 40 + p reduces to the output 40
+	expected := f.NewElement(40)
+	expected.Add(&expected, modulus)
+
+	hint := AssertCircuitOutput{
+		valuesPtr: hinter.Deref{Deref: hinter.ApCellRef(2)},
+		offset:    hinter.Immediate(f.NewElement(8)),
+		expected:  hinter.Immediate(expected),
+		modulus:   hinter.Immediate(*modulus),
+	}
+	require.NoError(t, hint.Execute(vm, nil))
+
+	hint.expected = hinter.Immediate(f.NewElement(41))
+	err = hint.Execute(vm, nil)
+	require.EqualError(t, err, "circuit output at 2:8 is 40, expected 41")
+}
+
+func TestAssertCircuitOutputUnreduced(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// output = 40 + p with p = 2^96 + 1, as left by an add-mod gate
+	values := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, values.SegmentIndex, 0, mem.MemoryValueFromInt(41))
+	utils.WriteTo(vm, values.SegmentIndex, 1, mem.MemoryValueFromInt(1))
+	utils.WriteTo(vm, values.SegmentIndex, 2, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, values.SegmentIndex, 3, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&values))
+
+	modulus, err := new(f.Element).SetString("79228162514264337593543950337")
+	require.NoError(t, err)
+
+	hint := AssertCircuitOutput{
+		valuesPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		offset:    hinter.Immediate(f.NewElement(0)),
+		expected:  hinter.Immediate(f.NewElement(40)),
+		modulus:   hinter.Immediate(*modulus),
+	}
+	require.NoError(t, hint.Execute(vm, nil))
+}
+
+func TestAssertCircuitOutputLimbOutOfRange(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	// a first limb of 2^96 + 40 is congruent to 39, but is not a valid UInt384 limb
+	limb, err := new(f.Element).SetString("79228162514264337593543950376")
+	require.NoError(t, err)
+
+	values := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, values.SegmentIndex, 0, mem.MemoryValueFromFieldElement(limb))
+	utils.WriteTo(vm, values.SegmentIndex, 1, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, values.SegmentIndex, 2, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, values.SegmentIndex, 3, mem.MemoryValueFromInt(0))
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&values))
+
+	modulus, err := new(f.Element).SetString("79228162514264337593543950337")
+	require.NoError(t, err)
+
+	hint := AssertCircuitOutput{
+		valuesPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		offset:    hinter.Immediate(f.NewElement(0)),
+		expected:  hinter.Immediate(f.NewElement(39)),
+		modulus:   hinter.Immediate(*modulus),
+	}
+	err = hint.Execute(vm, nil)
+	require.EqualError(t, err, "circuit output limb 0: 79228162514264337593543950376 does not fit in 96 bits")
+}
+
+func TestPointerOffset(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ptr           mem.MemoryAddress
+		base          mem.MemoryAddress
+		expected      int
+		errorExpected string
+	}{
+		{
+			name:     "TestPointerOffsetSameSegment",
+			ptr:      mem.MemoryAddress{SegmentIndex: 2, Offset: 17},
+			base:     mem.MemoryAddress{SegmentIndex: 2, Offset: 5},
+			expected: 12,
+		},
+		{
+			name:          "TestPointerOffsetCrossSegment",
+			ptr:           mem.MemoryAddress{SegmentIndex: 3, Offset: 17},
+			base:          mem.MemoryAddress{SegmentIndex: 2, Offset: 5},
+			errorExpected: "addresses are in different segments: rhs is in 2, lhs is in 3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&tc.ptr))
+			utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&tc.base))
+
+			hint := PointerOffset{
+				ptr:  hinter.Deref{Deref: hinter.ApCellRef(0)},
+				base: hinter.Deref{Deref: hinter.ApCellRef(1)},
+				dst:  hinter.ApCellRef(2),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.errorExpected != "" {
+				require.EqualError(t, err, tc.errorExpected)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, mem.MemoryValueFromInt(tc.expected), utils.ReadFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestPointerOffsetFeltBase(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromSegmentAndOffset(2, 17))
+
+	hint := PointerOffset{
+		ptr:  hinter.Deref{Deref: hinter.ApCellRef(0)},
+		base: hinter.Immediate(f.NewElement(5)),
+		dst:  hinter.ApCellRef(2),
+	}
+
+	err := hint.Execute(vm, nil)
+	require.EqualError(t, err, "base 5 is not an address")
+}
+
+func TestLegendreSymbol(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    int
+		expected int
+	}{
+		{
+			name:     "TestLegendreSymbolZero",
+			value:    0,
+			expected: 0,
+		},
+		{
+			name:     "TestLegendreSymbolResidue",
+			value:    4,
+			expected: 1,
+		},
+		{
+			// 3 generates the multiplicative group of the field, so it has no square root
+			name:     "TestLegendreSymbolNonResidue",
+			value:    3,
+			expected: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			hint := LegendreSymbol{
+				value: hinter.Immediate(f.NewElement(uint64(tc.value))),
+				dst:   hinter.ApCellRef(0),
+			}
+
+			require.NoError(t, hint.Execute(vm, nil))
+			require.Equal(t, mem.MemoryValueFromInt(tc.expected), utils.ReadFrom(vm, VM.ExecutionSegment, 0))
+		})
+	}
+}
+
+func TestBuildKeyIndexMap(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	accesses := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&accesses))
+
+	// each access is a (key, prev_value, new_value) triple
+	keys := []uint64{7, 3, 7, 11, 3, 7}
+	for i, key := range keys {
+		offset := uint64(i) * 3
+		utils.WriteTo(vm, accesses.SegmentIndex, offset, mem.MemoryValueFromUint(key))
+		utils.WriteTo(vm, accesses.SegmentIndex, offset+1, mem.MemoryValueFromInt(i))
+		utils.WriteTo(vm, accesses.SegmentIndex, offset+2, mem.MemoryValueFromInt(i+1))
+	}
+
+	ctx := hinter.InitializeDefaultContext()
+	hint := BuildKeyIndexMap{
+		accessPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		n:         hinter.Immediate(f.NewElement(uint64(len(keys)))),
+	}
+	require.NoError(t, hint.Execute(vm, ctx))
+
+	keyToIndices, err := hinter.GetVariableAs[map[f.Element][]uint64](&ctx.ScopeManager, "key_to_indices")
+	require.NoError(t, err)
+	require.Equal(t, map[f.Element][]uint64{
+		f.NewElement(7):  {0, 2, 5},
+		f.NewElement(3):  {1, 4},
+		f.NewElement(11): {3},
+	}, keyToIndices)
+}
+
+func TestBuildKeyIndexMapTooManyAccesses(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	accesses := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&accesses))
+
+	ctx := hinter.InitializeDefaultContext()
+	hint := BuildKeyIndexMap{
+		accessPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		n:         hinter.Immediate(f.NewElement(1 << 62)),
+	}
+	require.ErrorContains(t, hint.Execute(vm, ctx), "exceeds the maximum")
+}
+
+func TestAssertModN(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expectedN     uint64
+		errorExpected string
+	}{
+		{
+			name:      "TestAssertModNMatches",
+			expectedN: 3,
+		},
+		{
+			name:          "TestAssertModNMismatch",
+			expectedN:     2,
+			errorExpected: "mod builtin n is 3, expected 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			// p0..p3, values_ptr, offsets_ptr, n
+			modBuiltin := vm.Memory.AllocateEmptySegment()
+			values := vm.Memory.AllocateEmptySegment()
+			offsets := vm.Memory.AllocateEmptySegment()
+			for i := uint64(0); i < 4; i++ {
+				utils.WriteTo(vm, modBuiltin.SegmentIndex, i, mem.MemoryValueFromInt(0))
+			}
+			utils.WriteTo(vm, modBuiltin.SegmentIndex, 4, mem.MemoryValueFromMemoryAddress(&values))
+			utils.WriteTo(vm, modBuiltin.SegmentIndex, 5, mem.MemoryValueFromMemoryAddress(&offsets))
+			utils.WriteTo(vm, modBuiltin.SegmentIndex, 6, mem.MemoryValueFromInt(3))
+			utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&modBuiltin))
+
+			hint := AssertModN{
+				builtinPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+				expectedN:  hinter.Immediate(f.NewElement(tc.expectedN)),
+			}
+
+			err := hint.Execute(vm, nil)
+			if tc.errorExpected != "" {
+				require.EqualError(t, err, tc.errorExpected)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRootsOfUnity(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	roots := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&roots))
+
+	hint := RootsOfUnity{
+		order:  hinter.Immediate(f.NewElement(4)),
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+	require.NoError(t, hint.Execute(vm, nil))
+
+	// the primitive 4th root of unity w satisfies w^2 = -1
+	w, err := new(f.Element).SetString("0x625023929a2995b533120664329f8c7c5268e56ac8320da2a616626f41337e3")
+	require.NoError(t, err)
+	minusW, err := new(f.Element).SetString("0x1dafdc6d65d66b5accedf99bcd607383ad971a9537cdf25d59e99d90becc81e")
+	require.NoError(t, err)
+
+	expected := []mem.MemoryValue{
+		mem.MemoryValueFromInt(1),
+		mem.MemoryValueFromFieldElement(w),
+		mem.MemoryValueFromInt(-1),
+		mem.MemoryValueFromFieldElement(minusW),
+	}
+	for i, value := range expected {
+		require.Equal(t, value, utils.ReadFrom(vm, roots.SegmentIndex, uint64(i)))
+	}
+}
+
+func TestRootsOfUnityOrderNotDividing(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	roots := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&roots))
+
+	// P-1 = 2^192 * 5 * 7 * ... is not divisible by 3
+	hint := RootsOfUnity{
+		order:  hinter.Immediate(f.NewElement(3)),
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+	require.EqualError(
+		t,
+		hint.Execute(vm, nil),
+		"order 3 does not divide the multiplicative group order "+
+			"3618502788666131213697322783095070105623107215331596699973092056135872020480",
+	)
+}
+
+func TestRootsOfUnityInvalidOrder(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	roots := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&roots))
+
+	hint := RootsOfUnity{
+		order:  hinter.Immediate(f.NewElement(0)),
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+	require.EqualError(t, hint.Execute(vm, nil), "order must be positive")
+
+	// 2^60 divides P-1 but would write that many cells
+	hint.order = hinter.Immediate(f.NewElement(1 << 60))
+	require.ErrorContains(t, hint.Execute(vm, nil), "exceeds the maximum")
+}
+
+func TestU256AddCarry(t *testing.T) {
+	testCases := []struct {
+		name          string
+		aLow          string
+		aHigh         string
+		bLow          string
+		bHigh         string
+		expectedLow   string
+		expectedHigh  string
+		expectedCarry int
+	}{
+		{
+			name:          "TestU256AddCarryNoCarry",
+			aLow:          "0x5",
+			aHigh:         "0x1",
+			bLow:          "0x7",
+			bHigh:         "0x2",
+			expectedLow:   "0xc",
+			expectedHigh:  "0x3",
+			expectedCarry: 0,
+		},
+		{
+			name:          "TestU256AddCarryLowIntoHigh",
+			aLow:          "0xffffffffffffffffffffffffffffffff",
+			aHigh:         "0x1",
+			bLow:          "0x2",
+			bHigh:         "0x0",
+			expectedLow:   "0x1",
+			expectedHigh:  "0x2",
+			expectedCarry: 0,
+		},
+		{
+			name:          "TestU256AddCarryOutOfHigh",
+			aLow:          "0x3",
+			aHigh:         "0xffffffffffffffffffffffffffffffff",
+			bLow:          "0x4",
+			bHigh:         "0x1",
+			expectedLow:   "0x7",
+			expectedHigh:  "0x0",
+			expectedCarry: 1,
+		},
+		{
+			name:          "TestU256AddCarryThroughBothLimbs",
+			aLow:          "0xffffffffffffffffffffffffffffffff",
+			aHigh:         "0xffffffffffffffffffffffffffffffff",
+			bLow:          "0x1",
+			bHigh:         "0x0",
+			expectedLow:   "0x0",
+			expectedHigh:  "0x0",
+			expectedCarry: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm := VM.DefaultVirtualMachine()
+			vm.Context.Ap = 0
+			vm.Context.Fp = 0
+
+			limbs := make([]f.Element, 4)
+			for i, limb := range []string{tc.aLow, tc.aHigh, tc.bLow, tc.bHigh} {
+				_, err := limbs[i].SetString(limb)
+				require.NoError(t, err)
+			}
+
+			hint := U256AddCarry{
+				aLow:     hinter.Immediate(limbs[0]),
+				aHigh:    hinter.Immediate(limbs[1]),
+				bLow:     hinter.Immediate(limbs[2]),
+				bHigh:    hinter.Immediate(limbs[3]),
+				dstLow:   hinter.ApCellRef(0),
+				dstHigh:  hinter.ApCellRef(1),
+				dstCarry: hinter.ApCellRef(2),
+			}
+			require.NoError(t, hint.Execute(vm, nil))
+
+			expectedLow, err := new(f.Element).SetString(tc.expectedLow)
+			require.NoError(t, err)
+			expectedHigh, err := new(f.Element).SetString(tc.expectedHigh)
+			require.NoError(t, err)
+			require.Equal(t, mem.MemoryValueFromFieldElement(expectedLow), utils.ReadFrom(vm, VM.ExecutionSegment, 0))
+			require.Equal(t, mem.MemoryValueFromFieldElement(expectedHigh), utils.ReadFrom(vm, VM.ExecutionSegment, 1))
+			require.Equal(t, mem.MemoryValueFromInt(tc.expectedCarry), utils.ReadFrom(vm, VM.ExecutionSegment, 2))
+		})
+	}
+}
+
+func TestBitReversePermute(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	src := vm.Memory.AllocateEmptySegment()
+	dst := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&src))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&dst))
+	for i := 0; i < 8; i++ {
+		utils.WriteTo(vm, src.SegmentIndex, uint64(i), mem.MemoryValueFromInt(10+i))
+	}
+
+	hint := BitReversePermute{
+		srcPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len:    hinter.Immediate(f.NewElement(8)),
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+	}
+	require.NoError(t, hint.Execute(vm, nil))
+
+	// 0b001 <-> 0b100, 0b011 <-> 0b110, the palindromes stay in place
+	expected := []int{10, 14, 12, 16, 11, 15, 13, 17}
+	for i, value := range expected {
+		require.Equal(t, mem.MemoryValueFromInt(value), utils.ReadFrom(vm, dst.SegmentIndex, uint64(i)))
+	}
+}
+
+func TestBitReversePermuteInvalidLength(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	src := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&src))
+
+	hint := BitReversePermute{
+		srcPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+		len:    hinter.Immediate(f.NewElement(6)),
+		dstPtr: hinter.Deref{Deref: hinter.ApCellRef(0)},
+	}
+	require.EqualError(t, hint.Execute(vm, nil), "length 6 is not a power of two")
+
+	hint.len = hinter.Immediate(f.NewElement(1 << 62))
+	require.ErrorContains(t, hint.Execute(vm, nil), "exceeds the maximum")
+}
+
+func TestConstraintCombine(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	evals := vm.Memory.AllocateEmptySegment()
+	coeffs := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&evals))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&coeffs))
+	for i, value := range []int{2, 3, -5} {
+		utils.WriteTo(vm, evals.SegmentIndex, uint64(i), mem.MemoryValueFromInt(value))
+	}
+	for i, value := range []int{7, 11, 13} {
+		utils.WriteTo(vm, coeffs.SegmentIndex, uint64(i), mem.MemoryValueFromInt(value))
+	}
+
+	hint := ConstraintCombine{
+		evalsPtr:  hinter.Deref{Deref: hinter.ApCellRef(0)},
+		coeffsPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+		n:         hinter.Immediate(f.NewElement(3)),
+		point:     hinter.Immediate(f.NewElement(2)),
+		dst:       hinter.ApCellRef(2),
+	}
+	require.NoError(t, hint.Execute(vm, nil))
+
+	// 7*2 + 11*3*2 - 13*5*4 = 14 + 66 - 260
+	require.Equal(t, mem.MemoryValueFromInt(-180), utils.ReadFrom(vm, VM.ExecutionSegment, 2))
+}
+
+func TestConstraintCombineTooManyEvaluations(t *testing.T) {
+	vm := VM.DefaultVirtualMachine()
+	vm.Context.Ap = 0
+	vm.Context.Fp = 0
+
+	evals := vm.Memory.AllocateEmptySegment()
+	coeffs := vm.Memory.AllocateEmptySegment()
+	utils.WriteTo(vm, VM.ExecutionSegment, 0, mem.MemoryValueFromMemoryAddress(&evals))
+	utils.WriteTo(vm, VM.ExecutionSegment, 1, mem.MemoryValueFromMemoryAddress(&coeffs))
+
+	hint := ConstraintCombine{
+		evalsPtr:  hinter.Deref{Deref: hinter.ApCellRef(0)},
+		coeffsPtr: hinter.Deref{Deref: hinter.ApCellRef(1)},
+		n:         hinter.Immediate(f.NewElement(1 << 62)),
+		point:     hinter.Immediate(f.NewElement(2)),
+		dst:       hinter.ApCellRef(2),
+	}
+	require.ErrorContains(t, hint.Execute(vm, nil), "exceeds the maximum")
+}
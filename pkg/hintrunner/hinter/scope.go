@@ -2,6 +2,7 @@ package hinter
 
 import (
 	"fmt"
+	"math/big"
 )
 
 // ScopeManager handles all operations regarding scopes:
@@ -104,6 +105,26 @@ func GetVariableAs[T any](sm *ScopeManager, name string) (T, error) {
 	return typedValue, nil
 }
 
+// GetInt retrieves an int variable from the current scope, erroring if it holds a
+// value of another type
+func (sm *ScopeManager) GetInt(name string) (int, error) {
+	value, err := GetVariableAs[int](sm, name)
+	if err != nil {
+		return 0, fmt.Errorf("get int variable %s: %w", name, err)
+	}
+	return value, nil
+}
+
+// GetBigInt retrieves a *big.Int variable from the current scope, erroring if it holds
+// a value of another type
+func (sm *ScopeManager) GetBigInt(name string) (*big.Int, error) {
+	value, err := GetVariableAs[*big.Int](sm, name)
+	if err != nil {
+		return nil, fmt.Errorf("get big int variable %s: %w", name, err)
+	}
+	return value, nil
+}
+
 func (sm *ScopeManager) getCurrentScope() (*map[string]any, error) {
 	if len(sm.scopes) == 0 {
 		return nil, fmt.Errorf("expected at least one existing scope")
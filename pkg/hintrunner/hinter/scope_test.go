@@ -1,6 +1,7 @@
 package hinter
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -39,3 +40,26 @@ func TestScope(t *testing.T) {
 	err = sm.ExitScope()
 	require.ErrorContains(t, err, "expected at least one existing scope")
 }
+
+func TestScopeTypedGetters(t *testing.T) {
+	sm := DefaultNewScopeManager()
+	require.NoError(t, sm.AssignVariable("n", 3))
+	require.NoError(t, sm.AssignVariable("value", big.NewInt(42)))
+
+	n, err := sm.GetInt("n")
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+
+	value, err := sm.GetBigInt("value")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(42), value)
+
+	_, err = sm.GetInt("value")
+	require.EqualError(t, err, "get int variable value: value has a different type: value = 42, type = *big.Int, expected type = int")
+
+	_, err = sm.GetBigInt("n")
+	require.EqualError(t, err, "get big int variable n: value has a different type: value = 3, type = int, expected type = *big.Int")
+
+	_, err = sm.GetInt("x")
+	require.EqualError(t, err, "get int variable x: variable x not found in current scope")
+}
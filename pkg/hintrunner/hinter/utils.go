@@ -49,3 +49,20 @@ func ResolveAsUint64(vm *VM.VirtualMachine, op Reference) (uint64, error) {
 
 	return uint64Value, nil
 }
+
+// MaxLength bounds the lengths and sizes resolved from memory by hints which then read,
+// write or allocate that many cells, so that a malformed operand errors instead of
+// exhausting the host memory
+const MaxLength = 1 << 22
+
+// ResolveAsLength resolves op as a length, erroring if it is larger than MaxLength
+func ResolveAsLength(vm *VM.VirtualMachine, op Reference) (uint64, error) {
+	length, err := ResolveAsUint64(vm, op)
+	if err != nil {
+		return 0, err
+	}
+	if length > MaxLength {
+		return 0, fmt.Errorf("%s: length %d exceeds the maximum of %d", op, length, MaxLength)
+	}
+	return length, nil
+}
@@ -66,6 +66,14 @@ func Divmod(n, m, p *big.Int) (big.Int, error) {
 	return *res, nil
 }
 
+// Errors if the felt is bigger than 2**128 - 1
+func AssertU128(v *fp.Element) error {
+	if v.Cmp(&utils.FeltMax128) >= 0 {
+		return fmt.Errorf("%s should be u128", v)
+	}
+	return nil
+}
+
 func IsQuadResidue(x *fp.Element) bool {
 	// Implementation adapted from sympy implementation which can be found here :
 	// https://github.com/sympy/sympy/blob/d91b8ad6d36a59a879cc70e5f4b379da5fdd46ce/sympy/ntheory/residue_ntheory.py#L689
@@ -114,3 +122,25 @@ func GetCairoPrime() (big.Int, bool) {
 	cairoPrime, ok := new(big.Int).SetString("3618502788666131213697322783095070105623107215331596699973092056135872020481", 10)
 	return *cairoPrime, ok
 }
+
+// Returns the stark field prime 2**251 + 17 * 2**192 + 1
+func FieldModulus() *big.Int {
+	return fp.Modulus()
+}
+
+// Returns the stark field prime as 32 big-endian bytes
+func FieldPrimeBytes() [32]byte {
+	var primeBytes [32]byte
+	fp.Modulus().FillBytes(primeBytes[:])
+	return primeBytes
+}
+
+// Returns the alpha coefficient of the stark curve y**2 = x**3 + alpha * x + beta
+func StarkCurveAlpha() *big.Int {
+	return utils.Alpha.BigInt(new(big.Int))
+}
+
+// Returns the beta coefficient of the stark curve y**2 = x**3 + alpha * x + beta
+func StarkCurveBeta() *big.Int {
+	return utils.Beta.BigInt(new(big.Int))
+}
@@ -3,6 +3,8 @@ package utils
 import (
 	"math/big"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 )
 
 func TestDivMod(t *testing.T) {
@@ -46,3 +48,74 @@ func TestDivMod(t *testing.T) {
 		})
 	}
 }
+
+func TestAssertU128(t *testing.T) {
+	maxU128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+	tests := []struct {
+		name           string
+		value          *big.Int
+		expectedErrMsg string
+	}{
+		{
+			name:  "Zero",
+			value: big.NewInt(0),
+		},
+		{
+			name:  "Max u128",
+			value: maxU128,
+		},
+		{
+			name:           "2**128",
+			value:          new(big.Int).Add(maxU128, big.NewInt(1)),
+			expectedErrMsg: "340282366920938463463374607431768211456 should be u128",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AssertU128(new(fp.Element).SetBigInt(tt.value))
+
+			if tt.expectedErrMsg == "" {
+				if err != nil {
+					t.Errorf("got error: %v, want: nil", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != tt.expectedErrMsg {
+				t.Errorf("got error: %v, want: %v", err, tt.expectedErrMsg)
+			}
+		})
+	}
+}
+
+func TestFieldConstants(t *testing.T) {
+	// 2**251 + 17 * 2**192 + 1
+	prime, _ := new(big.Int).SetString("3618502788666131213697322783095070105623107215331596699973092056135872020481", 10)
+	if FieldModulus().Cmp(prime) != 0 {
+		t.Errorf("got field modulus: %v, want: %v", FieldModulus(), prime)
+	}
+
+	primeBytes := FieldPrimeBytes()
+	if new(big.Int).SetBytes(primeBytes[:]).Cmp(prime) != 0 {
+		t.Errorf("got field prime bytes: %x, want: %x", primeBytes, prime)
+	}
+
+	// returned values are copies, so modifying them leaves the constants unchanged
+	FieldModulus().SetInt64(0)
+	if FieldModulus().Cmp(prime) != 0 {
+		t.Errorf("field modulus was modified by a caller")
+	}
+}
+
+func TestStarkCurveConstants(t *testing.T) {
+	if StarkCurveAlpha().Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("got curve alpha: %v, want: 1", StarkCurveAlpha())
+	}
+
+	beta, _ := new(big.Int).SetString("0x6f21413efbe40de150e596d72f7a8c5609ad26c15c915c1f4cdfcb99cee9e89", 0)
+	if StarkCurveBeta().Cmp(beta) != 0 {
+		t.Errorf("got curve beta: %v, want: %v", StarkCurveBeta(), beta)
+	}
+}
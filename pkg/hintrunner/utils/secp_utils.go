@@ -70,6 +70,40 @@ func SecPPackedBigInt5(limbs [5]*fp.Element) (big.Int, error) {
 	return *packedBig, nil
 }
 
+// Packs BigInt3 limbs in base 2**86 into their integer value, the limbs being
+// interpreted as signed felts
+func PackBigInt3(limbs [3]*fp.Element) (*big.Int, error) {
+	packed, err := SecPPacked(limbs)
+	if err != nil {
+		return nil, err
+	}
+	return &packed, nil
+}
+
+// Splits a non-negative value into BigInt3 limbs in base 2**86, each of them
+// smaller than 2**86. Errors if the value does not fit in 3 * 86 bits
+func UnpackBigInt3(value *big.Int) ([3]*fp.Element, error) {
+	const limbBitLen = 86
+
+	if value.Sign() < 0 {
+		return [3]*fp.Element{}, fmt.Errorf("value %s should be non-negative", value)
+	}
+	if value.BitLen() > 3*limbBitLen {
+		return [3]*fp.Element{}, fmt.Errorf("value %s should fit in %d bits", value, 3*limbBitLen)
+	}
+
+	mask := new(big.Int).Lsh(big.NewInt(1), limbBitLen)
+	mask.Sub(mask, big.NewInt(1))
+
+	var limbs [3]*fp.Element
+	for i := range limbs {
+		limb := new(big.Int).Rsh(value, uint(i*limbBitLen))
+		limb.And(limb, mask)
+		limbs[i] = new(fp.Element).SetBigInt(limb)
+	}
+	return limbs, nil
+}
+
 func GetBetaBig() big.Int {
 	return *big.NewInt(7)
 }
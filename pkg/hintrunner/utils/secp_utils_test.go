@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPackUnpackBigInt3(t *testing.T) {
+	secP, ok := GetSecPBig()
+	if !ok {
+		t.Fatal("GetSecPBig failed")
+	}
+	n, ok := GetN()
+	if !ok {
+		t.Fatal("GetN failed")
+	}
+	maxValue := new(big.Int).Lsh(big.NewInt(1), 3*86)
+	maxValue.Sub(maxValue, big.NewInt(1))
+	limbBound := new(big.Int).Lsh(big.NewInt(1), 86)
+
+	tests := []struct {
+		name  string
+		value *big.Int
+	}{
+		{
+			name:  "Zero",
+			value: big.NewInt(0),
+		},
+		{
+			name:  "Secp256k1 prime",
+			value: &secP,
+		},
+		{
+			name:  "Secp256k1 prime minus one",
+			value: new(big.Int).Sub(&secP, big.NewInt(1)),
+		},
+		{
+			name:  "Secp256k1 group order",
+			value: &n,
+		},
+		{
+			name:  "Max value",
+			value: maxValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limbs, err := UnpackBigInt3(tt.value)
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+
+			for i, limb := range limbs {
+				var limbBig big.Int
+				limb.BigInt(&limbBig)
+				if limbBig.Cmp(limbBound) >= 0 {
+					t.Errorf("limb %d: got %v, want < 2**86", i, &limbBig)
+				}
+			}
+
+			packed, err := PackBigInt3(limbs)
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if packed.Cmp(tt.value) != 0 {
+				t.Errorf("got packed value: %v, want: %v", packed, tt.value)
+			}
+		})
+	}
+}
+
+func TestUnpackBigInt3TooBig(t *testing.T) {
+	value := new(big.Int).Lsh(big.NewInt(1), 3*86)
+
+	_, err := UnpackBigInt3(value)
+	expectedErrMsg := value.String() + " should fit in 258 bits"
+	if err == nil || err.Error() != "value "+expectedErrMsg {
+		t.Errorf("got error: %v, want: value %v", err, expectedErrMsg)
+	}
+}
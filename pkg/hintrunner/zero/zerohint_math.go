@@ -388,7 +388,7 @@ func createAssertLeFeltExcluded2Hinter() (hinter.Hinter, error) {
 	h := &GenericZeroHinter{
 		Name: "AssertLeFeltExcluded2",
 		Op: func(vm *VM.VirtualMachine, ctx *hinter.HintRunnerContext) error {
-			excluded, err := ctx.ScopeManager.GetVariableValue("excluded")
+			excluded, err := ctx.ScopeManager.GetInt("excluded")
 			if err != nil {
 				return err
 			}
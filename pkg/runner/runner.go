@@ -345,11 +345,17 @@ func (runner *Runner) initializeBuiltins(memory *mem.Memory) ([]mem.MemoryValue,
 	for _, bRunner := range runner.layout.Builtins {
 		if runner.runnerMode == ExecutionModeCairo {
 			if slices.Contains(runner.program.Builtins, bRunner.Builtin) {
-				builtinSegment := memory.AllocateBuiltinSegment(bRunner.Runner)
+				builtinSegment, err := memory.AllocateBuiltinSegmentChecked(bRunner.Runner)
+				if err != nil {
+					return []mem.MemoryValue{}, err
+				}
 				stack = append(stack, mem.MemoryValueFromMemoryAddress(&builtinSegment))
 			}
 		} else {
-			builtinSegment := memory.AllocateBuiltinSegment(bRunner.Runner)
+			builtinSegment, err := memory.AllocateBuiltinSegmentChecked(bRunner.Runner)
+			if err != nil {
+				return []mem.MemoryValue{}, err
+			}
 			if slices.Contains(runner.program.Builtins, bRunner.Builtin) {
 				stack = append(stack, mem.MemoryValueFromMemoryAddress(&builtinSegment))
 			}
@@ -564,7 +570,7 @@ func (runner *Runner) FinalizeSegments() error {
 }
 
 // BuildMemory relocates the memory and returns it
-func (runner *Runner) BuildMemory() ([]*fp.Element, []uint64) {
+func (runner *Runner) BuildMemory() ([]*fp.Element, []uint64, error) {
 	return runner.vm.RelocateMemory()
 }
 
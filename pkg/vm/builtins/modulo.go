@@ -120,6 +120,11 @@ func (m *ModBuiltin) String() string {
 	return string(m.modBuiltinType) + ModuloName
 }
 
+// Returns whether the builtin is an AddMod or a MulMod builtin
+func (m *ModBuiltin) ModBuiltinType() ModBuiltinType {
+	return m.modBuiltinType
+}
+
 func (m *ModBuiltin) GetAllocatedSize(segmentUsedSize uint64, vmCurrentStep uint64) (uint64, error) {
 	return 0, nil
 }
@@ -318,49 +323,108 @@ func (m *ModBuiltin) writeNWordsValue(mem *memory.Memory, addr memory.MemoryAddr
 	return nil
 }
 
+// Reads the (lhs, rhs, res) offsets of the operation at the given index
+func (m *ModBuiltin) operationOffsets(mem *memory.Memory, inputs ModBuiltinInputs, index int) ([3]uint64, error) {
+	var offsets [3]uint64
+	for i := 0; i < 3; i++ {
+		addr, err := inputs.offsetsPtr.AddOffset(int16(3*index + i))
+		if err != nil {
+			return offsets, err
+		}
+		offsetFelt, err := mem.ReadAsElement(addr.SegmentIndex, addr.Offset)
+		if err != nil {
+			return offsets, err
+		}
+		if !offsetFelt.IsUint64() {
+			return offsets, fmt.Errorf("offset %s at address %s is not a uint64", offsetFelt.String(), addr)
+		}
+		offsets[i] = offsetFelt.Uint64()
+	}
+	return offsets, nil
+}
+
+// Labels an error raised while filling the operation at the given index with
+// the operation number and its offsets, to locate the failing gate in the circuit
+func (m *ModBuiltin) operationError(mem *memory.Memory, inputs ModBuiltinInputs, index int, err error) error {
+	offsets, offsetsErr := m.operationOffsets(mem, inputs, index)
+	if offsetsErr != nil {
+		return fmt.Errorf("%s operation %d: %w", m, index, err)
+	}
+	return fmt.Errorf(
+		"%s operation %d with offsets (lhs: %d, rhs: %d, res: %d): %w",
+		m, index, offsets[0], offsets[1], offsets[2], err,
+	)
+}
+
 // Fills a value in the values table, if exactly one value is missing.
 // Returns 1 on success or if all values are already known.
 // Returns 0 if there is an error or is the value cannot be filled
 // Returns 2 when the mulModBuiltin has a zero divisor.
-// Given known, res, p fillValue tries to compute the minimal integer operand x which
-// satisfies the equation op(x,known) = res + k*p for some k in {0,1,...,self.k_bound-1}.
 func (m *ModBuiltin) fillValue(mem *memory.Memory, inputs ModBuiltinInputs, index int, op ModBuiltinType) (int, error) {
-	addresses := make([]memory.MemoryAddress, 0, 3)
-	values := make([]*big.Int, 0, 3)
+	addresses, values, err := m.readOperation(mem, inputs, index)
+	if err != nil {
+		return 0, err
+	}
+	missing, value, res, err := m.computeValue(inputs, values, op)
+	if err != nil {
+		return 0, err
+	}
+	if missing < 0 {
+		return res, nil
+	}
+	if err := m.writeNWordsValue(mem, addresses[missing], value); err != nil {
+		return 0, err
+	}
+	return res, nil
+}
+
+// Reads the addresses and values of the (lhs, rhs, res) operands of the operation at
+// the given index. Operands that are not in memory yet are returned as nil values.
+func (m *ModBuiltin) readOperation(mem *memory.Memory, inputs ModBuiltinInputs, index int) ([3]memory.MemoryAddress, [3]*big.Int, error) {
+	var addresses [3]memory.MemoryAddress
+	var values [3]*big.Int
 
 	for i := 0; i < 3; i++ {
 		addr, err := inputs.offsetsPtr.AddOffset(int16(3*index + i))
 		if err != nil {
-			return 0, err
+			return addresses, values, err
 		}
 		offsetFelt, err := mem.ReadAsElement(addr.SegmentIndex, addr.Offset)
 		if err != nil {
-			return 0, err
+			return addresses, values, err
 		}
 		offset := offsetFelt.Uint64()
 		addr, err = inputs.valuesPtr.AddOffset(int16(offset))
 		if err != nil {
-			return 0, err
+			return addresses, values, err
 		}
-		addresses = append(addresses, addr)
+		addresses[i] = addr
 		// do not check for all errors, as the value might not be in memory
 		// only check for the error when the value in memory exceeds 2**wordBitLen
 		_, value, err := m.readNWordsValue(mem, addr)
 		if err != nil {
 			if strings.Contains(err.Error(), "expected integer at address") {
-				return 0, err
+				return addresses, values, err
 			}
 		}
-		values = append(values, value)
+		values[i] = value
 	}
+	return addresses, values, nil
+}
 
+// Given known, res, p computeValue tries to compute the minimal integer operand x which
+// satisfies the equation op(x,known) = res + k*p for some k in {0,1,...,self.k_bound-1}.
+// It does not access memory: it returns the index of the missing operand (-1 if there is
+// none) and its value, along with the same status code as fillValue.
+func (m *ModBuiltin) computeValue(inputs ModBuiltinInputs, values [3]*big.Int, op ModBuiltinType) (int, big.Int, int, error) {
 	a, b, c := values[0], values[1], values[2]
 
 	// 2 ** 384 (max value that can be stored in 4 felts)
 	intLim := new(big.Int).Lsh(big.NewInt(1), uint(m.wordBitLen)*N_WORDS)
-	kBound := m.kBound
-	if kBound == nil {
-		kBound = new(big.Int).Set(intLim)
+	// kBound is copied since the error messages below modify it
+	kBound := new(big.Int).Set(intLim)
+	if m.kBound != nil {
+		kBound.Set(m.kBound)
 	}
 	switch {
 	case a != nil && b != nil && c == nil:
@@ -372,17 +436,14 @@ func (m *ModBuiltin) fillValue(mem *memory.Memory, inputs ModBuiltinInputs, inde
 		}
 		// value - (kBound - 1) * p <= intLim - 1
 		if new(big.Int).Sub(&value, new(big.Int).Mul((new(big.Int).Sub(kBound, big.NewInt(1))), &inputs.p)).Cmp(new(big.Int).Sub(intLim, big.NewInt(1))) == 1 {
-			return 0, fmt.Errorf("%s builtin: Expected a %s b - %d * p <= %d", m.String(), m.modBuiltinType, kBound.Sub(kBound, big.NewInt(1)), intLim.Sub(intLim, big.NewInt(1)))
+			return -1, big.Int{}, 0, fmt.Errorf("Expected a %s b - %d * p <= %d", m.modBuiltinType, kBound.Sub(kBound, big.NewInt(1)), intLim.Sub(intLim, big.NewInt(1)))
 		}
 		if value.Cmp(new(big.Int).Mul(kBound, &inputs.p)) < 0 {
 			value.Mod(&value, &inputs.p)
 		} else {
 			value.Sub(&value, new(big.Int).Mul(new(big.Int).Sub(kBound, big.NewInt(1)), &inputs.p))
 		}
-		if err := m.writeNWordsValue(mem, addresses[2], value); err != nil {
-			return 0, err
-		}
-		return 1, nil
+		return 2, value, 1, nil
 	case a != nil && b == nil && c != nil:
 		zeroDivisor := false
 		var value big.Int
@@ -390,7 +451,7 @@ func (m *ModBuiltin) fillValue(mem *memory.Memory, inputs ModBuiltinInputs, inde
 			// Right now only k = 2 is an option, hence as we stated above that x + known can only take values
 			// from res to res + (k - 1) * p, hence known <= res + p
 			if a.Cmp(new(big.Int).Add(c, &inputs.p)) > 0 {
-				return 0, fmt.Errorf("%s builtin: addend greater than sum + p: %d > %d + %d", m.String(), a, c, &inputs.p)
+				return -1, big.Int{}, 0, fmt.Errorf("addend greater than sum + p: %d > %d + %d", a, c, &inputs.p)
 			} else {
 				if a.Cmp(c) <= 0 {
 					value = *new(big.Int).Sub(c, a)
@@ -409,23 +470,20 @@ func (m *ModBuiltin) fillValue(mem *memory.Memory, inputs ModBuiltinInputs, inde
 				value = *value.Mod(&value, &inputs.p)
 				tmpK, err := utils.SafeDiv(new(big.Int).Sub(new(big.Int).Mul(a, &value), c), &inputs.p)
 				if err != nil {
-					return 0, err
+					return -1, big.Int{}, 0, err
 				}
 				if tmpK.Cmp(kBound) >= 0 {
-					return 0, fmt.Errorf("%s builtin: ((%d * q) - %d) / %d > %d for any q > 0, such that %d * q = %d (mod %d) ", m.String(), a, c, &inputs.p, kBound, a, c, &inputs.p)
+					return -1, big.Int{}, 0, fmt.Errorf("((%d * q) - %d) / %d > %d for any q > 0, such that %d * q = %d (mod %d)", a, c, &inputs.p, kBound, a, c, &inputs.p)
 				}
 				if tmpK.Cmp(big.NewInt(0)) < 0 {
 					value = *value.Add(&value, new(big.Int).Mul(&inputs.p, new(big.Int).Div(new(big.Int).Sub(a, new(big.Int).Sub(&tmpK, big.NewInt(1))), a)))
 				}
 			}
 		}
-		if err := m.writeNWordsValue(mem, addresses[1], value); err != nil {
-			return 0, err
-		}
 		if zeroDivisor {
-			return 2, nil
+			return 1, value, 2, nil
 		}
-		return 1, nil
+		return 1, value, 1, nil
 	case a == nil && b != nil && c != nil:
 		zeroDivisor := false
 		var value big.Int
@@ -433,7 +491,7 @@ func (m *ModBuiltin) fillValue(mem *memory.Memory, inputs ModBuiltinInputs, inde
 			// Right now only k = 2 is an option, hence as we stated above that x + known can only take values
 			// from res to res + (k - 1) * p, hence known <= res + p
 			if b.Cmp(new(big.Int).Add(c, &inputs.p)) > 0 {
-				return 0, fmt.Errorf("%s builtin: addend greater than sum + p: %d > %d + %d", m.String(), b, c, &inputs.p)
+				return -1, big.Int{}, 0, fmt.Errorf("addend greater than sum + p: %d > %d + %d", b, c, &inputs.p)
 			} else {
 				if b.Cmp(c) <= 0 {
 					value = *new(big.Int).Sub(c, b)
@@ -452,27 +510,24 @@ func (m *ModBuiltin) fillValue(mem *memory.Memory, inputs ModBuiltinInputs, inde
 				value = *value.Mod(&value, &inputs.p)
 				tmpK, err := utils.SafeDiv(new(big.Int).Sub(new(big.Int).Mul(b, &value), c), &inputs.p)
 				if err != nil {
-					return 0, err
+					return -1, big.Int{}, 0, err
 				}
 				if tmpK.Cmp(kBound) >= 0 {
-					return 0, fmt.Errorf("%s builtin: ((%d * q) - %d) / %d > %d for any q > 0, such that %d * q = %d (mod %d) ", m.String(), b, c, &inputs.p, kBound, b, c, &inputs.p)
+					return -1, big.Int{}, 0, fmt.Errorf("((%d * q) - %d) / %d > %d for any q > 0, such that %d * q = %d (mod %d)", b, c, &inputs.p, kBound, b, c, &inputs.p)
 				}
 				if tmpK.Cmp(big.NewInt(0)) < 0 {
 					value = *value.Add(&value, new(big.Int).Mul(&inputs.p, new(big.Int).Div(new(big.Int).Sub(b, new(big.Int).Sub(&tmpK, big.NewInt(1))), b)))
 				}
 			}
 		}
-		if err := m.writeNWordsValue(mem, addresses[0], value); err != nil {
-			return 0, err
-		}
 		if zeroDivisor {
-			return 2, nil
+			return 0, value, 2, nil
 		}
-		return 1, nil
+		return 0, value, 1, nil
 	case a != nil && b != nil && c != nil:
-		return 1, nil
+		return -1, big.Int{}, 1, nil
 	default:
-		return 0, nil
+		return -1, big.Int{}, 0, nil
 	}
 }
 
@@ -544,7 +599,7 @@ func FillMemory(mem *memory.Memory, addModInputAddress memory.MemoryAddress, nAd
 		if addModIndex < nAddMods && addModBuiltinRunner != nil {
 			res, err := addModBuiltinRunner.fillValue(mem, addModBuiltinInputs, int(addModIndex), Add)
 			if err != nil {
-				return err
+				return addModBuiltinRunner.operationError(mem, addModBuiltinInputs, int(addModIndex), err)
 			}
 			if res == 1 {
 				addModIndex++
@@ -554,10 +609,10 @@ func FillMemory(mem *memory.Memory, addModInputAddress memory.MemoryAddress, nAd
 		if mulModIndex < nMulMods && mulModBuiltinRunner != nil {
 			res, err := mulModBuiltinRunner.fillValue(mem, mulModBuiltinInputs, int(mulModIndex), Mul)
 			if err != nil {
-				return err
+				return mulModBuiltinRunner.operationError(mem, mulModBuiltinInputs, int(mulModIndex), err)
 			}
 			if res == 0 {
-				return fmt.Errorf("MulMod builtin: Could not fill the values table")
+				return mulModBuiltinRunner.operationError(mem, mulModBuiltinInputs, int(mulModIndex), fmt.Errorf("could not fill the values table"))
 			}
 			if res == 2 && nComputedMulGates == 0 {
 				nComputedMulGates = mulModIndex
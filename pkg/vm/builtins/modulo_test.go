@@ -156,3 +156,66 @@ func TestMulModBuiltinRunnerDivision(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, big.NewInt(22), res5)
 }
+
+/*
+Builds a MulMod circuit over p with the given batch size, in which value j of the values
+table is set to values[j] when present and left unknown otherwise, and operation i reads
+the values at offsets[3i], offsets[3i+1] and offsets[3i+2].
+Returns the memory and the MulMod builtin pointer.
+*/
+func buildMulModCircuit(p int64, batchSize uint64, values map[int]int64, offsets []int) (*memory.Memory, memory.MemoryAddress, error) {
+	mem := memory.InitializeEmptyMemory()
+	valuesPtr := mem.AllocateEmptySegment()
+	offsetsPtr := mem.AllocateEmptySegment()
+	runner := NewModBuiltin(1, 96, batchSize, Mul)
+	mulModPtr := mem.AllocateBuiltinSegment(runner)
+
+	for j, value := range values {
+		addr, err := valuesPtr.AddOffset(int16(j * N_WORDS))
+		if err != nil {
+			return nil, mulModPtr, err
+		}
+		if err := runner.writeNWordsValue(mem, addr, *big.NewInt(value)); err != nil {
+			return nil, mulModPtr, err
+		}
+	}
+	for i, offset := range offsets {
+		mv := memory.MemoryValueFromInt(offset * N_WORDS)
+		if err := mem.Write(offsetsPtr.SegmentIndex, uint64(i), &mv); err != nil {
+			return nil, mulModPtr, err
+		}
+	}
+
+	if err := runner.writeNWordsValue(mem, mulModPtr, *big.NewInt(p)); err != nil {
+		return nil, mulModPtr, err
+	}
+	inputs := []memory.MemoryValue{
+		memory.MemoryValueFromMemoryAddress(&valuesPtr),
+		memory.MemoryValueFromMemoryAddress(&offsetsPtr),
+		memory.MemoryValueFromInt(len(offsets) / 3),
+	}
+	for i := range inputs {
+		if err := mem.Write(mulModPtr.SegmentIndex, uint64(VALUES_PTR_OFFSET+i), &inputs[i]); err != nil {
+			return nil, mulModPtr, err
+		}
+	}
+	return mem, mulModPtr, nil
+}
+
+func TestFillMemoryMulModNonInvertible(t *testing.T) {
+	// 1 * 1 = 1, then 2 * x = 4 (mod 6) where 2 has no inverse modulo 6
+	values := map[int]int64{0: 1, 1: 1, 2: 1, 3: 2, 5: 4}
+	mem, mulModPtr, err := buildMulModCircuit(6, 2, values, []int{0, 1, 2, 3, 4, 5})
+	require.NoError(t, err)
+	err = FillMemory(mem, memory.UnknownAddress, 0, mulModPtr, 2)
+	require.ErrorContains(t, err, "Inverse failure is supported only at batch_size == 1")
+}
+
+func TestFillMemoryMulModUnresolvable(t *testing.T) {
+	// x * y = 1 (mod 7) with both operands unknown
+	mem, mulModPtr, err := buildMulModCircuit(7, 1, map[int]int64{2: 1}, []int{0, 1, 2})
+	require.NoError(t, err)
+
+	err = FillMemory(mem, memory.UnknownAddress, 0, mulModPtr, 1)
+	require.EqualError(t, err, "MulMod operation 0 with offsets (lhs: 0, rhs: 4, res: 8): could not fill the values table")
+}
@@ -27,6 +27,18 @@ type RangeCheck struct {
 	ratio            uint64
 	RangeCheckNParts uint64
 	stopPointer      uint64
+	// maximum number of cells of the segment, zero when unbounded
+	capacity uint64
+}
+
+// NewRangeCheck creates a range check builtin checking values of rangeCheckNParts 16-bit
+// parts, whose segment can hold at most capacity cells, or any number of cells if it is zero
+func NewRangeCheck(ratio uint64, rangeCheckNParts uint64, capacity uint64) *RangeCheck {
+	return &RangeCheck{
+		ratio:            ratio,
+		RangeCheckNParts: rangeCheckNParts,
+		capacity:         capacity,
+	}
 }
 
 func (r *RangeCheck) CheckWrite(segment *memory.Segment, offset uint64, value *memory.MemoryValue) error {
@@ -126,3 +138,7 @@ func (r *RangeCheck) GetStopPointer() uint64 {
 func (r *RangeCheck) SetStopPointer(stopPointer uint64) {
 	r.stopPointer = stopPointer
 }
+
+func (r *RangeCheck) Capacity() (uint64, bool) {
+	return r.capacity, r.capacity != 0
+}
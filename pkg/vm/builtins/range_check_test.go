@@ -10,13 +10,13 @@ import (
 )
 
 func TestRangeCheckWriteMemoryAddress(t *testing.T) {
-	builtin := RangeCheck{0, 8, 0}
+	builtin := RangeCheck{RangeCheckNParts: 8}
 	memoryAddress := memory.EmptyMemoryValueAsAddress()
 	assert.Error(t, builtin.CheckWrite(nil, 0, &memoryAddress))
 }
 
 func TestRangeCheckWriteOutOfRange(t *testing.T) {
-	builtin := RangeCheck{0, 8, 0}
+	builtin := RangeCheck{RangeCheckNParts: 8}
 	outOfRangeValueFelt, err := new(fp.Element).SetString("0x100000000000000000000000000000001")
 	require.NoError(t, err)
 	outOfRangeValue := memory.MemoryValueFromFieldElement(outOfRangeValueFelt)
@@ -24,7 +24,7 @@ func TestRangeCheckWriteOutOfRange(t *testing.T) {
 }
 
 func TestRangeCheckWrite(t *testing.T) {
-	builtin := RangeCheck{0, 8, 0}
+	builtin := RangeCheck{RangeCheckNParts: 8}
 	f, err := new(fp.Element).SetString("0x44")
 	require.NoError(t, err)
 	v := memory.MemoryValueFromFieldElement(f)
@@ -32,19 +32,19 @@ func TestRangeCheckWrite(t *testing.T) {
 }
 
 func TestRangeCheckInfer(t *testing.T) {
-	builtin := RangeCheck{0, 8, 0}
+	builtin := RangeCheck{RangeCheckNParts: 8}
 	segment := memory.EmptySegmentWithLength(3)
 	assert.ErrorContains(t, builtin.InferValue(segment, 0), "cannot infer value")
 }
 
 func TestRangeCheck96WriteMemoryAddress(t *testing.T) {
-	builtin := RangeCheck{0, 6, 0}
+	builtin := RangeCheck{RangeCheckNParts: 6}
 	memoryAddress := memory.EmptyMemoryValueAsAddress()
 	assert.Error(t, builtin.CheckWrite(nil, 0, &memoryAddress))
 }
 
 func TestRangeCheck96WriteOutOfRange(t *testing.T) {
-	builtin := RangeCheck{0, 6, 0}
+	builtin := RangeCheck{RangeCheckNParts: 6}
 	outOfRangeValueFelt, err := new(fp.Element).SetString("40564819207303340847894502572032")
 	require.NoError(t, err)
 	outOfRangeValue := memory.MemoryValueFromFieldElement(outOfRangeValueFelt)
@@ -52,7 +52,7 @@ func TestRangeCheck96WriteOutOfRange(t *testing.T) {
 }
 
 func TestRangeCheck96Write(t *testing.T) {
-	builtin := RangeCheck{0, 6, 0}
+	builtin := RangeCheck{RangeCheckNParts: 6}
 	f, err := new(fp.Element).SetString("19342813113834066795298816")
 	require.NoError(t, err)
 	v := memory.MemoryValueFromFieldElement(f)
@@ -60,7 +60,39 @@ func TestRangeCheck96Write(t *testing.T) {
 }
 
 func TestRangeCheck96Infer(t *testing.T) {
-	builtin := RangeCheck{0, 6, 0}
+	builtin := RangeCheck{RangeCheckNParts: 6}
 	segment := memory.EmptySegmentWithLength(3)
 	assert.ErrorContains(t, builtin.InferValue(segment, 0), "cannot infer value")
 }
+
+func TestAllocateRangeCheckTwice(t *testing.T) {
+	mem := memory.InitializeEmptyMemory()
+
+	rangeCheck := NewRangeCheck(8, 8, 0)
+	_, err := mem.AllocateBuiltinSegmentChecked(rangeCheck)
+	require.NoError(t, err)
+
+	// range checks with other bounds are distinct runners sharing the same name
+	_, err = mem.AllocateBuiltinSegmentChecked(NewRangeCheck(8, 8, 16))
+	require.NoError(t, err)
+	_, err = mem.AllocateBuiltinSegmentChecked(NewRangeCheck(8, 6, 0))
+	require.NoError(t, err)
+
+	_, err = mem.AllocateBuiltinSegmentChecked(rangeCheck)
+	require.EqualError(t, err, "builtin range_check is already allocated")
+	require.Len(t, mem.Segments, 3)
+}
+
+func TestRangeCheckWriteOutOfCapacity(t *testing.T) {
+	mem := memory.InitializeEmptyMemory()
+	segment := mem.AllocateBuiltinSegment(NewRangeCheck(0, 8, 2))
+
+	value := memory.MemoryValueFromInt(7)
+	require.NoError(t, mem.Write(segment.SegmentIndex, 0, &value))
+	require.NoError(t, mem.Write(segment.SegmentIndex, 1, &value))
+	require.EqualError(
+		t,
+		mem.Write(segment.SegmentIndex, 2, &value),
+		"segment 0, offset 2: range_check: offset 2 is out of bounds, segment capacity is 2",
+	)
+}
@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Memory access errors are wrapped with their segment and offset context, so
+// callers should match them with errors.Is and errors.As
+var (
+	// Returned when reading a cell that was never written and whose value
+	// cannot be inferred
+	ErrUnknownCell = errors.New("reading unknown value")
+	// Returned when accessing a segment that was never allocated
+	ErrSegmentOutOfRange = errors.New("unallocated")
+)
+
+// Returned when a memory value is not of the kind the caller expected, e.g. when
+// reading a felt out of a cell holding an address
+type TypeMismatchError struct {
+	Expected string
+	Actual   string
+}
+
+func (e *TypeMismatchError) Error() string {
+	switch e.Expected {
+	case addrMemoryValue.String():
+		return "memory value is not an address"
+	case feltMemoryValue.String():
+		return "memory value is not a field element"
+	default:
+		return fmt.Sprintf("memory value is not %s: got %s", e.Expected, e.Actual)
+	}
+}
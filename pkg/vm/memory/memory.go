@@ -1,7 +1,6 @@
 package memory
 
 import (
-	"errors"
 	"fmt"
 
 	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
@@ -17,6 +16,13 @@ type BuiltinRunner interface {
 	SetStopPointer(stopPointer uint64)
 }
 
+// BoundedBuiltinRunner is implemented by builtin runners whose segment can only hold a
+// limited number of cells. Writes at or past that bound are rejected
+type BoundedBuiltinRunner interface {
+	// Returns the number of cells the segment can hold, and false if it is unbounded
+	Capacity() (uint64, bool)
+}
+
 type NoBuiltin struct{}
 
 func (b *NoBuiltin) CheckWrite(segment *Segment, offset uint64, value *MemoryValue) error {
@@ -24,7 +30,7 @@ func (b *NoBuiltin) CheckWrite(segment *Segment, offset uint64, value *MemoryVal
 }
 
 func (b *NoBuiltin) InferValue(segment *Segment, offset uint64) error {
-	return errors.New("reading unknown value")
+	return ErrUnknownCell
 }
 
 func (b *NoBuiltin) String() string {
@@ -97,6 +103,11 @@ func (segment *Segment) RealLen() uint64 {
 // Writes a new memory value to a specified offset, errors in case of overwriting a
 // different memory value
 func (segment *Segment) Write(offset uint64, value *MemoryValue) error {
+	if bounded, ok := segment.BuiltinRunner.(BoundedBuiltinRunner); ok {
+		if capacity, ok := bounded.Capacity(); ok && offset >= capacity {
+			return fmt.Errorf("%s: offset %d is out of bounds, segment capacity is %d", segment.BuiltinRunner, offset, capacity)
+		}
+	}
 	if offset >= segment.RealLen() {
 		segment.IncreaseSegmentSize(offset + 1)
 	}
@@ -154,7 +165,7 @@ func (segment *Segment) IncreaseSegmentSize(newSize uint64) {
 	}
 
 	var newSegmentData []MemoryValue
-	if cap(segmentData) > int(newSize) {
+	if cap(segmentData) >= int(newSize) {
 		newSegmentData = segmentData[:cap(segmentData)]
 	} else {
 		newSegmentData = make([]MemoryValue, max(newSize, uint64(len(segmentData)*2)))
@@ -163,6 +174,26 @@ func (segment *Segment) IncreaseSegmentSize(newSize uint64) {
 	segment.Data = newSegmentData
 }
 
+// Returns a copy of the segment with its own cells and public memory offsets.
+// The builtin runner is shared with the original segment
+func (segment *Segment) DeepCopy() *Segment {
+	data := make([]MemoryValue, len(segment.Data), cap(segment.Data))
+	copy(data, segment.Data)
+
+	var publicMemoryOffsets []PublicMemoryOffset
+	if segment.PublicMemoryOffsets != nil {
+		publicMemoryOffsets = make([]PublicMemoryOffset, len(segment.PublicMemoryOffsets))
+		copy(publicMemoryOffsets, segment.PublicMemoryOffsets)
+	}
+
+	return &Segment{
+		Data:                data,
+		LastIndex:           segment.LastIndex,
+		BuiltinRunner:       segment.BuiltinRunner,
+		PublicMemoryOffsets: publicMemoryOffsets,
+	}
+}
+
 func (segment *Segment) Finalize(newSize uint64, publicMemoryOffsets []PublicMemoryOffset) {
 	if newSize > 0 {
 		segment.LastIndex = int(newSize - 1)
@@ -228,6 +259,33 @@ func InitializeEmptyMemory() *Memory {
 	}
 }
 
+// Returns a copy of the memory whose segments, temporary segments and relocation
+// rules can be mutated without affecting the original. Builtin runners are not
+// cloned: both memories point to the same runner instances, so any state a runner
+// keeps (e.g. its stop pointer) is shared between them
+func (memory *Memory) DeepCopy() *Memory {
+	segments := make([]*Segment, len(memory.Segments), cap(memory.Segments))
+	for i := range memory.Segments {
+		segments[i] = memory.Segments[i].DeepCopy()
+	}
+
+	temporarySegments := make([]*Segment, len(memory.TemporarySegments))
+	for i := range memory.TemporarySegments {
+		temporarySegments[i] = memory.TemporarySegments[i].DeepCopy()
+	}
+
+	relocationRules := make(map[int]MemoryAddress, len(memory.relocationRules))
+	for segmentIndex, addr := range memory.relocationRules {
+		relocationRules[segmentIndex] = addr
+	}
+
+	return &Memory{
+		Segments:          segments,
+		TemporarySegments: temporarySegments,
+		relocationRules:   relocationRules,
+	}
+}
+
 // Allocates a new segment providing its initial data and returns its index
 func (memory *Memory) AllocateSegment(data []*f.Element) (MemoryAddress, error) {
 	newSegment := EmptySegmentWithLength(len(data))
@@ -254,6 +312,16 @@ func (memory *Memory) AllocateEmptySegment() MemoryAddress {
 	}
 }
 
+// Allocates an empty segment whose backing array can hold capacity cells
+// without growing, and returns its index
+func (memory *Memory) AllocateEmptySegmentWithCapacity(capacity int) MemoryAddress {
+	memory.Segments = append(memory.Segments, EmptySegmentWithCapacity(capacity))
+	return MemoryAddress{
+		SegmentIndex: len(memory.Segments) - 1,
+		Offset:       0,
+	}
+}
+
 // Allocates an empty temporary segment and returns its index
 func (memory *Memory) AllocateEmptyTemporarySegment() MemoryAddress {
 	memory.TemporarySegments = append(memory.TemporarySegments, EmptySegment())
@@ -273,12 +341,25 @@ func (memory *Memory) AllocateBuiltinSegment(builtinRunner BuiltinRunner) Memory
 	}
 }
 
+// Works the same as AllocateBuiltinSegment, but errors if the same builtin runner
+// already has a segment, so that a runner shared by two layout entries is caught early.
+// Distinct runners of the same builtin, e.g. range checks with different bounds, each
+// get their own segment
+func (memory *Memory) AllocateBuiltinSegmentChecked(builtinRunner BuiltinRunner) (MemoryAddress, error) {
+	for _, segment := range memory.Segments {
+		if segment.BuiltinRunner == builtinRunner {
+			return UnknownAddress, fmt.Errorf("builtin %s is already allocated", builtinRunner)
+		}
+	}
+	return memory.AllocateBuiltinSegment(builtinRunner), nil
+}
+
 // Writes to a given segment index and offset a new memory value. Errors if writing
 // to an unallocated segment or if overwriting a different memory value
 func (memory *Memory) Write(segmentIndex int, offset uint64, value *MemoryValue) error {
 	if segmentIndex >= 0 {
 		if segmentIndex >= len(memory.Segments) {
-			return fmt.Errorf("segment %d: unallocated", segmentIndex)
+			return fmt.Errorf("segment %d: %w", segmentIndex, ErrSegmentOutOfRange)
 		}
 		if err := memory.Segments[segmentIndex].Write(offset, value); err != nil {
 			return fmt.Errorf("segment %d, offset %d: %w", segmentIndex, offset, err)
@@ -287,7 +368,7 @@ func (memory *Memory) Write(segmentIndex int, offset uint64, value *MemoryValue)
 	} else {
 		segmentIndex = -segmentIndex
 		if segmentIndex >= len(memory.TemporarySegments) {
-			return fmt.Errorf("temporary segment %d: unallocated", segmentIndex)
+			return fmt.Errorf("temporary segment %d: %w", segmentIndex, ErrSegmentOutOfRange)
 		}
 		if err := memory.TemporarySegments[segmentIndex].Write(offset, value); err != nil {
 			return fmt.Errorf("temporary segment %d, offset %d: %w", segmentIndex, offset, err)
@@ -307,7 +388,7 @@ func (memory *Memory) WriteToAddress(address *MemoryAddress, value *MemoryValue)
 func (memory *Memory) Read(segmentIndex int, offset uint64) (MemoryValue, error) {
 	if segmentIndex >= 0 {
 		if segmentIndex >= len(memory.Segments) {
-			return MemoryValue{}, fmt.Errorf("segment %d: unallocated", segmentIndex)
+			return MemoryValue{}, fmt.Errorf("segment %d: %w", segmentIndex, ErrSegmentOutOfRange)
 		}
 		mv, err := memory.Segments[segmentIndex].Read(offset)
 		if err != nil {
@@ -317,7 +398,7 @@ func (memory *Memory) Read(segmentIndex int, offset uint64) (MemoryValue, error)
 	} else {
 		segmentIndex = -segmentIndex
 		if segmentIndex >= len(memory.TemporarySegments) {
-			return MemoryValue{}, fmt.Errorf("temporary segment %d: unallocated", segmentIndex)
+			return MemoryValue{}, fmt.Errorf("temporary segment %d: %w", segmentIndex, ErrSegmentOutOfRange)
 		}
 		mv, err := memory.TemporarySegments[segmentIndex].Read(offset)
 		if err != nil {
@@ -369,21 +450,29 @@ func (memory *Memory) ReadFromAddressAsAddress(address *MemoryAddress) (MemoryAd
 	return memory.ReadAsAddress(address)
 }
 
-// Given a segment index and offset, returns the memory value at that position, without
-// modifying it in any way. Errors if peeking from an unallocated segment
-func (memory *Memory) Peek(segmentIndex int, offset uint64) (MemoryValue, error) {
+// Returns the segment with the given index, where negative indexes refer to temporary
+// segments. Errors if the segment is unallocated
+func (memory *Memory) segment(segmentIndex int) (*Segment, error) {
 	if segmentIndex >= 0 {
 		if segmentIndex >= len(memory.Segments) {
-			return MemoryValue{}, fmt.Errorf("segment %d: unallocated", segmentIndex)
+			return nil, fmt.Errorf("segment %d: %w", segmentIndex, ErrSegmentOutOfRange)
 		}
-		return memory.Segments[segmentIndex].Peek(offset), nil
-	} else {
-		segmentIndex = -segmentIndex
-		if segmentIndex >= len(memory.TemporarySegments) {
-			return MemoryValue{}, fmt.Errorf("temporary segment %d: unallocated", segmentIndex)
-		}
-		return memory.TemporarySegments[segmentIndex].Peek(offset), nil
+		return memory.Segments[segmentIndex], nil
+	}
+	if -segmentIndex >= len(memory.TemporarySegments) {
+		return nil, fmt.Errorf("temporary segment %d: %w", -segmentIndex, ErrSegmentOutOfRange)
 	}
+	return memory.TemporarySegments[-segmentIndex], nil
+}
+
+// Given a segment index and offset, returns the memory value at that position, without
+// modifying it in any way. Errors if peeking from an unallocated segment
+func (memory *Memory) Peek(segmentIndex int, offset uint64) (MemoryValue, error) {
+	segment, err := memory.segment(segmentIndex)
+	if err != nil {
+		return MemoryValue{}, err
+	}
+	return segment.Peek(offset), nil
 }
 
 // Given an address returns the memory value at that position, without
@@ -392,6 +481,62 @@ func (memory *Memory) PeekFromAddress(address *MemoryAddress) (MemoryValue, erro
 	return memory.Peek(address.SegmentIndex, address.Offset)
 }
 
+// Returns the raw value stored at an address and whether it is known. Like `Peek`, it
+// never triggers a builtin deduction, and it reports unallocated segments as unknown
+// values, which makes it suitable for debuggers inspecting arbitrary addresses
+func (memory *Memory) PeekKnown(address MemoryAddress) (MemoryValue, bool) {
+	mv, err := memory.PeekFromAddress(&address)
+	if err != nil {
+		return UnknownValue, false
+	}
+	return mv, mv.Known()
+}
+
+// Calls fn with each known cell of a segment in ascending offset order, skipping the
+// holes, and stops at the first error returned by fn. Like `Peek`, it never triggers a
+// builtin deduction. Negative indexes refer to temporary segments
+func (memory *Memory) IterateSegment(segmentIndex int, fn func(offset uint64, value MemoryValue) error) error {
+	segment, err := memory.segment(segmentIndex)
+	if err != nil {
+		return err
+	}
+
+	for offset := range segment.Data {
+		if !segment.Data[offset].Known() {
+			continue
+		}
+		if err := fn(uint64(offset), segment.Data[offset]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the largest offset of a segment holding a known value, and false if the
+// segment has no known value or does not exist. Negative indexes refer to temporary
+// segments
+func (memory *Memory) MaxUsedOffset(segmentIndex int) (uint64, bool) {
+	var segment *Segment
+	if segmentIndex >= 0 {
+		if segmentIndex >= len(memory.Segments) {
+			return 0, false
+		}
+		segment = memory.Segments[segmentIndex]
+	} else {
+		if -segmentIndex >= len(memory.TemporarySegments) {
+			return 0, false
+		}
+		segment = memory.TemporarySegments[-segmentIndex]
+	}
+
+	for offset := len(segment.Data) - 1; offset >= 0; offset-- {
+		if segment.Data[offset].Known() {
+			return uint64(offset), true
+		}
+	}
+	return 0, false
+}
+
 // Given a segment index and offset returns true if the value at that address
 // is known
 func (memory *Memory) KnownValue(segment int, offset uint64) bool {
@@ -416,6 +561,37 @@ func (memory *Memory) KnownValueAtAddress(address *MemoryAddress) bool {
 	return memory.KnownValue(address.SegmentIndex, address.Offset)
 }
 
+// Returns, for each segment, the number of cells holding a known value. Unlike the
+// segment length, it does not count the unwritten holes between cells
+func (memory *Memory) CountWrittenCells() []uint64 {
+	counts := make([]uint64, len(memory.Segments))
+	for i, segment := range memory.Segments {
+		for j := range segment.Data {
+			if segment.Data[j].Known() {
+				counts[i]++
+			}
+		}
+	}
+	return counts
+}
+
+// Returns, in ascending order, the offsets of the segment cells holding a value equal
+// to target. It scans the whole segment and is intended for debugging only
+func (memory *Memory) FindValue(segmentIndex int, target MemoryValue) []uint64 {
+	segment, err := memory.segment(segmentIndex)
+	if err != nil {
+		return nil
+	}
+
+	var offsets []uint64
+	for i := range segment.Data {
+		if segment.Data[i].Known() && segment.Data[i].Equal(&target) {
+			offsets = append(offsets, uint64(i))
+		}
+	}
+	return offsets
+}
+
 // It returns all segment offsets and max memory used
 func (memory *Memory) RelocationOffsets() ([]uint64, uint64) {
 	// Prover expects maxMemoryUsed to start at one
@@ -434,6 +610,51 @@ func (memory *Memory) RelocationOffsets() ([]uint64, uint64) {
 	return segmentsOffsets, maxMemoryUsed
 }
 
+// It returns all segments relocated into a single flat array of felts, together with
+// the segment offsets used. Addresses are relocated and, as in cairo-lang, cells that were
+// never written are filled with zero. Index zero is unused since the prover expects the
+// relocated memory to start at one
+func (memory *Memory) Relocate() ([]*f.Element, []uint64, error) {
+	relocatedMemory, segmentsOffsets, err := memory.RelocateSparse()
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range relocatedMemory {
+		if relocatedMemory[i] == nil {
+			relocatedMemory[i] = new(f.Element)
+		}
+	}
+	return relocatedMemory, segmentsOffsets, nil
+}
+
+// It works the same as Relocate, but cells that were never written are left as nil
+func (memory *Memory) RelocateSparse() ([]*f.Element, []uint64, error) {
+	segmentsOffsets, maxMemoryUsed := memory.RelocationOffsets()
+	relocatedMemory := make([]*f.Element, maxMemoryUsed)
+	for i, segment := range memory.Segments {
+		for j := uint64(0); j < segment.RealLen(); j++ {
+			if !segment.Data[j].Known() {
+				continue
+			}
+
+			var felt *f.Element
+			if segment.Data[j].IsAddress() {
+				addr := segment.Data[j].addrUnsafe()
+				if addr.SegmentIndex < 0 || addr.SegmentIndex >= len(memory.Segments) {
+					return nil, nil, fmt.Errorf(
+						"cell %d:%d points to %s which is not a relocatable segment", i, j, addr,
+					)
+				}
+				felt = addr.Relocate(segmentsOffsets)
+			} else {
+				felt = new(f.Element).Set(&segment.Data[j].Felt)
+			}
+			relocatedMemory[segmentsOffsets[i]+j] = felt
+		}
+	}
+	return relocatedMemory, segmentsOffsets, nil
+}
+
 // It finds a segment with a given builtin name, it returns the segment and true if found
 func (memory *Memory) FindSegmentWithBuiltin(builtinName string) (*Segment, bool) {
 	for i := range memory.Segments {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	f "github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -145,6 +146,40 @@ func TestMemoryReadUnallocated(t *testing.T) {
 	require.ErrorContains(t, err, "unallocated")
 }
 
+func TestMemoryTypedErrors(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+
+	// unallocated segments, both regular and temporary
+	_, err := memory.Read(1, 0)
+	require.ErrorIs(t, err, ErrSegmentOutOfRange)
+	err = memory.Write(-1, 0, memoryValuePointerFromInt(1))
+	require.ErrorIs(t, err, ErrSegmentOutOfRange)
+	_, err = memory.Peek(3, 0)
+	require.ErrorIs(t, err, ErrSegmentOutOfRange)
+
+	// reading a cell that was never written
+	_, err = memory.Read(0, 2)
+	require.ErrorIs(t, err, ErrUnknownCell)
+
+	// reading a cell with the wrong kind
+	err = memory.Write(0, 0, memoryValuePointerFromInt(5))
+	require.NoError(t, err)
+	_, err = memory.ReadAsAddress(&MemoryAddress{SegmentIndex: 0, Offset: 0})
+
+	var mismatch *TypeMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "address", mismatch.Expected)
+	assert.Equal(t, "felt", mismatch.Actual)
+
+	address := MemoryValueFromSegmentAndOffset(0, 0)
+	_, err = address.ExpectFelt("lhs")
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "felt", mismatch.Expected)
+	assert.Equal(t, "address", mismatch.Actual)
+	assert.EqualError(t, err, "lhs: memory value is not a field element")
+}
+
 func TestMemoryPeek(t *testing.T) {
 	memory := InitializeEmptyMemory()
 	memory.AllocateEmptySegment()
@@ -228,6 +263,167 @@ func TestSegmentBuiltin(t *testing.T) {
 	})
 }
 
+func TestMemoryPeekKnown(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	builtinSegment := memory.AllocateBuiltinSegment(&testBuiltin{})
+	require.NoError(t, memory.Write(0, 0, memoryValuePointerFromInt(7)))
+
+	mv, known := memory.PeekKnown(MemoryAddress{SegmentIndex: 0, Offset: 0})
+	assert.True(t, known)
+	assert.Equal(t, MemoryValueFromInt(7), mv)
+
+	// peeking an undeduced builtin cell leaves it untouched
+	cell := MemoryAddress{SegmentIndex: builtinSegment.SegmentIndex, Offset: 2}
+	_, known = memory.PeekKnown(cell)
+	assert.False(t, known)
+	assert.False(t, memory.KnownValueAtAddress(&cell))
+
+	// while reading it makes the builtin deduce its value
+	mv, err := memory.ReadFromAddress(&cell)
+	require.NoError(t, err)
+	assert.Equal(t, MemoryValueFromInt(2), mv)
+
+	mv, known = memory.PeekKnown(cell)
+	assert.True(t, known)
+	assert.Equal(t, MemoryValueFromInt(2), mv)
+
+	_, known = memory.PeekKnown(MemoryAddress{SegmentIndex: 5, Offset: 0})
+	assert.False(t, known)
+}
+
+func TestMemoryIterateSegment(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	require.NoError(t, memory.Write(0, 1, memoryValuePointerFromInt(10)))
+	require.NoError(t, memory.Write(0, 4, memoryValuePointerFromInt(40)))
+	require.NoError(t, memory.Write(0, 5, memoryValuePointerFromInt(50)))
+
+	offsets := []uint64{}
+	values := []MemoryValue{}
+	err := memory.IterateSegment(0, func(offset uint64, value MemoryValue) error {
+		offsets = append(offsets, offset)
+		values = append(values, value)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 4, 5}, offsets)
+	assert.Equal(t, []MemoryValue{MemoryValueFromInt(10), MemoryValueFromInt(40), MemoryValueFromInt(50)}, values)
+
+	// the callback error stops the iteration
+	stop := fmt.Errorf("stop")
+	offsets = []uint64{}
+	err = memory.IterateSegment(0, func(offset uint64, value MemoryValue) error {
+		offsets = append(offsets, offset)
+		if offset == 4 {
+			return stop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, stop)
+	assert.Equal(t, []uint64{1, 4}, offsets)
+
+	err = memory.IterateSegment(3, func(offset uint64, value MemoryValue) error {
+		return nil
+	})
+	require.ErrorIs(t, err, ErrSegmentOutOfRange)
+}
+
+func TestMemoryMaxUsedOffset(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	memory.AllocateEmptySegment()
+	require.NoError(t, memory.Write(0, 2, memoryValuePointerFromInt(20)))
+	require.NoError(t, memory.Write(0, 9, memoryValuePointerFromInt(90)))
+
+	// reading past the last written cell grows the segment with unknown values
+	_, err := memory.Read(0, 15)
+	require.Error(t, err)
+
+	offset, ok := memory.MaxUsedOffset(0)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(9), offset)
+
+	_, ok = memory.MaxUsedOffset(1)
+	assert.False(t, ok)
+
+	_, ok = memory.MaxUsedOffset(2)
+	assert.False(t, ok)
+}
+
+func TestMemoryDeepCopy(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	builtinSegment := memory.AllocateBuiltinSegment(&testBuiltin{})
+	temporarySegment := memory.AllocateEmptyTemporarySegment()
+
+	address := MemoryValueFromSegmentAndOffset(1, 4)
+	require.NoError(t, memory.Write(0, 0, memoryValuePointerFromInt(10)))
+	require.NoError(t, memory.Write(0, 1, &address))
+	require.NoError(t, memory.Write(temporarySegment.SegmentIndex, 0, memoryValuePointerFromInt(20)))
+
+	clone := memory.DeepCopy()
+
+	// mutate the clone: overwrite a felt and an address cell, and grow both segments
+	clone.Segments[0].Data[0] = MemoryValueFromInt(11)
+	clone.Segments[0].Data[1] = MemoryValueFromSegmentAndOffset(1, 5)
+	require.NoError(t, clone.Write(0, 50, memoryValuePointerFromInt(12)))
+	require.NoError(t, clone.Write(temporarySegment.SegmentIndex, 1, memoryValuePointerFromInt(21)))
+	clone.AllocateEmptySegment()
+	clone.AddRelocationRule(1, MemoryAddress{0, 0})
+
+	read, err := memory.Read(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, MemoryValueFromInt(10), read)
+
+	read, err = memory.Read(0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, address, read)
+
+	assert.Equal(t, uint64(2), memory.Segments[0].Len())
+	assert.False(t, memory.KnownValue(0, 50))
+	assert.False(t, memory.KnownValue(temporarySegment.SegmentIndex, 1))
+	assert.Equal(t, 2, len(memory.Segments))
+	assert.Empty(t, memory.relocationRules)
+
+	// builtin runners are shared between the copies
+	assert.Same(
+		t,
+		memory.Segments[builtinSegment.SegmentIndex].BuiltinRunner,
+		clone.Segments[builtinSegment.SegmentIndex].BuiltinRunner,
+	)
+}
+
+func TestCountWrittenCells(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	memory.AllocateEmptySegment()
+
+	require.NoError(t, memory.Write(1, 0, memoryValuePointerFromInt(1)))
+	require.NoError(t, memory.Write(1, 5, memoryValuePointerFromInt(2)))
+	require.NoError(t, memory.Write(1, 10, memoryValuePointerFromInt(3)))
+
+	assert.Equal(t, []uint64{0, 3}, memory.CountWrittenCells())
+	assert.Equal(t, uint64(11), memory.Segments[1].Len())
+}
+
+func TestFindValue(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+
+	address := MemoryValueFromSegmentAndOffset(0, 7)
+	require.NoError(t, memory.Write(0, 9, memoryValuePointerFromInt(7)))
+	require.NoError(t, memory.Write(0, 2, memoryValuePointerFromInt(7)))
+	require.NoError(t, memory.Write(0, 4, memoryValuePointerFromInt(8)))
+	require.NoError(t, memory.Write(0, 5, &address))
+	require.NoError(t, memory.Write(0, 6, memoryValuePointerFromInt(7)))
+
+	assert.Equal(t, []uint64{2, 6, 9}, memory.FindValue(0, MemoryValueFromInt(7)))
+	assert.Equal(t, []uint64{5}, memory.FindValue(0, address))
+	assert.Empty(t, memory.FindValue(0, MemoryValueFromInt(3)))
+	assert.Empty(t, memory.FindValue(1, MemoryValueFromInt(7)))
+}
+
 func TestRelocationOffsets(t *testing.T) {
 	memory := InitializeEmptyMemory()
 	memory.AllocateEmptySegment() //Program
@@ -262,6 +458,52 @@ func TestRelocationOffsets(t *testing.T) {
 	assert.Equal(t, memoryUsed, uint64(7))
 }
 
+func TestMemoryRelocate(t *testing.T) {
+	// segment 0: [3, -, 1:1]
+	// segment 1: [7, 9]
+	// relocated: [0, 3, 0, 5, 7, 9]
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	memory.AllocateEmptySegment()
+
+	err := memory.Write(0, 0, memoryValuePointerFromInt(3))
+	require.NoError(t, err)
+	pointer := MemoryValueFromSegmentAndOffset(1, 1)
+	err = memory.Write(0, 2, &pointer)
+	require.NoError(t, err)
+	err = memory.Write(1, 0, memoryValuePointerFromInt(7))
+	require.NoError(t, err)
+	err = memory.Write(1, 1, memoryValuePointerFromInt(9))
+	require.NoError(t, err)
+
+	relocated, offsets, err := memory.Relocate()
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 4, 6}, offsets)
+
+	expected := []*f.Element{
+		new(f.Element),
+		new(f.Element).SetUint64(3),
+		new(f.Element),
+		new(f.Element).SetUint64(5),
+		new(f.Element).SetUint64(7),
+		new(f.Element).SetUint64(9),
+	}
+	assert.Equal(t, expected, relocated)
+}
+
+func TestMemoryRelocateTemporaryPointer(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	tempSegment := memory.AllocateEmptyTemporarySegment()
+
+	pointer := MemoryValueFromMemoryAddress(&tempSegment)
+	err := memory.Write(0, 0, &pointer)
+	require.NoError(t, err)
+
+	_, _, err = memory.Relocate()
+	require.ErrorContains(t, err, "is not a relocatable segment")
+}
+
 // compares the memory value match an expected value at the given segment and offset
 func noErrorAndEqualSegmentRead(t *testing.T, s *Segment, offset uint64, expected MemoryValue) {
 	v, err := s.Read(offset)
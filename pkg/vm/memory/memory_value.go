@@ -1,8 +1,13 @@
 package memory
 
 import (
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 	"unsafe"
 
 	"github.com/NethermindEth/cairo-vm-go/pkg/utils"
@@ -34,15 +39,22 @@ func (address *MemoryAddress) Cmp(other *MemoryAddress) int {
 	}
 }
 
+// Orders addresses by segment index and then by offset. Its signature makes it
+// usable as a comparator for slices.SortFunc
+func CompareMemoryAddresses(a, b MemoryAddress) int {
+	return a.Cmp(&b)
+}
+
 // It crates a new memory address with the modified offset
 func (address *MemoryAddress) AddOffset(offset int16) (MemoryAddress, error) {
 	newOffset, overflow := utils.SafeOffset(address.Offset, offset)
 	if overflow {
+		if offset < 0 {
+			return UnknownAddress,
+				fmt.Errorf("offset underflow: %d - %d", address.Offset, -int32(offset))
+		}
 		return UnknownAddress,
-			fmt.Errorf(
-				"address new invalid offseet: %d + %d = %d",
-				address.Offset, offset, newOffset,
-			)
+			fmt.Errorf("offset overflow: %d + %d", address.Offset, offset)
 	}
 	return MemoryAddress{
 		SegmentIndex: address.SegmentIndex,
@@ -122,6 +134,17 @@ const (
 	addrMemoryValue
 )
 
+func (kind memoryValueKind) String() string {
+	switch kind {
+	case feltMemoryValue:
+		return "felt"
+	case addrMemoryValue:
+		return "address"
+	default:
+		return "unknown"
+	}
+}
+
 var UnknownValue = MemoryValue{}
 
 func MemoryValueFromMemoryAddress(address *MemoryAddress) MemoryValue {
@@ -157,6 +180,14 @@ func MemoryValueFromUint[T constraints.Unsigned](v T) MemoryValue {
 	}
 }
 
+// creates a felt memory value from a big integer, reducing it modulo the field prime.
+// Negative values are mapped to their field equivalent, i.e. -1 becomes p - 1
+func MemoryValueFromBigInt(v *big.Int) MemoryValue {
+	value := MemoryValue{Kind: feltMemoryValue}
+	value.Felt.SetBigInt(v)
+	return value
+}
+
 // creates a memory value from an index and an offset. If either is negative the result is
 // undefined
 func MemoryValueFromSegmentAndOffset[T constraints.Integer](segmentIndex, offset T) MemoryValue {
@@ -176,6 +207,8 @@ func MemoryValueFromAny(anyType any) (MemoryValue, error) {
 		return MemoryValueFromUint(anyType), nil
 	case *f.Element:
 		return MemoryValueFromFieldElement(anyType), nil
+	case *big.Int:
+		return MemoryValueFromBigInt(anyType), nil
 	case *MemoryAddress:
 		return MemoryValueFromMemoryAddress(anyType), nil
 	default:
@@ -207,18 +240,38 @@ func EmptyMemoryValueAs(address bool) MemoryValue {
 
 func (mv *MemoryValue) MemoryAddress() (*MemoryAddress, error) {
 	if !mv.IsAddress() {
-		return nil, errors.New("memory value is not an address")
+		return nil, &TypeMismatchError{Expected: addrMemoryValue.String(), Actual: mv.Kind.String()}
 	}
 	return mv.addrUnsafe(), nil
 }
 
 func (mv *MemoryValue) FieldElement() (*f.Element, error) {
 	if !mv.IsFelt() {
-		return nil, fmt.Errorf("memory value is not a field element")
+		return nil, &TypeMismatchError{Expected: feltMemoryValue.String(), Actual: mv.Kind.String()}
 	}
 	return &mv.Felt, nil
 }
 
+// Returns the memory value as a field element, or an error labeled with context
+// describing which value was expected
+func (mv *MemoryValue) ExpectFelt(context string) (*f.Element, error) {
+	felt, err := mv.FieldElement()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", context, err)
+	}
+	return felt, nil
+}
+
+// Returns the memory value as an address, or an error labeled with context
+// describing which value was expected
+func (mv *MemoryValue) ExpectAddress(context string) (*MemoryAddress, error) {
+	address, err := mv.MemoryAddress()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", context, err)
+	}
+	return address, nil
+}
+
 func (mv *MemoryValue) Any() any {
 	if mv.IsAddress() {
 		return mv.addrUnsafe()
@@ -249,6 +302,17 @@ func (mv *MemoryValue) IsZero() bool {
 	}
 }
 
+func (mv *MemoryValue) IsOne() bool {
+	switch mv.Kind {
+	case addrMemoryValue:
+		return false
+	case feltMemoryValue:
+		return mv.Felt.IsOne()
+	default:
+		return false
+	}
+}
+
 func (mv *MemoryValue) Equal(other *MemoryValue) bool {
 	if mv.IsAddress() && other.IsAddress() {
 		return mv.addrUnsafe().Equal(other.addrUnsafe())
@@ -259,6 +323,18 @@ func (mv *MemoryValue) Equal(other *MemoryValue) bool {
 	return false
 }
 
+// Compares two memory values of the same kind. Felts are compared by their
+// value, addresses by segment index and then offset
+func (mv *MemoryValue) Cmp(other *MemoryValue) (int, error) {
+	if mv.IsAddress() && other.IsAddress() {
+		return mv.addrUnsafe().Cmp(other.addrUnsafe()), nil
+	}
+	if mv.IsFelt() && other.IsFelt() {
+		return mv.Felt.Cmp(&other.Felt), nil
+	}
+	return 0, fmt.Errorf("cannot compare memory values of different kinds: %s, %s", mv, other)
+}
+
 // Adds two memory values if the second one is a Felt
 func (mv *MemoryValue) Add(lhs, rhs *MemoryValue) error {
 	if lhs.IsAddress() {
@@ -347,6 +423,45 @@ func (mv *MemoryValue) Div(lhs, rhs *MemoryValue) error {
 	return nil
 }
 
+// Doubles a memory value if it is a Felt
+func (mv *MemoryValue) Double(v *MemoryValue) error {
+	if v.IsAddress() {
+		return errors.New("cannot double a memory address")
+	}
+	mv.Felt.Double(&v.Felt)
+	return nil
+}
+
+// Squares a memory value if it is a Felt
+func (mv *MemoryValue) Square(v *MemoryValue) error {
+	if v.IsAddress() {
+		return errors.New("cannot square a memory address")
+	}
+	mv.Felt.Square(&v.Felt)
+	return nil
+}
+
+// Inverts a memory value if it is a non zero Felt
+func (mv *MemoryValue) Inv(v *MemoryValue) error {
+	if v.IsAddress() {
+		return errors.New("cannot invert a memory address")
+	}
+	if v.Felt.IsZero() {
+		return errors.New("cannot invert zero")
+	}
+	mv.Felt.Inverse(&v.Felt)
+	return nil
+}
+
+// Negates a memory value if it is a Felt
+func (mv *MemoryValue) Neg(v *MemoryValue) error {
+	if v.IsAddress() {
+		return errors.New("cannot negate a memory address")
+	}
+	mv.Felt.Neg(&v.Felt)
+	return nil
+}
+
 func (mv MemoryValue) String() string {
 	if mv.IsAddress() {
 		return mv.addrUnsafe().String()
@@ -354,6 +469,133 @@ func (mv MemoryValue) String() string {
 	return mv.Felt.String()
 }
 
+// Encodes the memory value as a tag byte holding its kind followed by either the
+// felt as 32 big-endian bytes, or the address segment (int64) and offset (uint64)
+// as big-endian integers. Unknown values are encoded as the tag byte alone
+func (mv MemoryValue) MarshalBinary() ([]byte, error) {
+	switch mv.Kind {
+	case feltMemoryValue:
+		felt := mv.Felt.Bytes()
+		return append([]byte{byte(feltMemoryValue)}, felt[:]...), nil
+	case addrMemoryValue:
+		address := mv.addrUnsafe()
+		data := make([]byte, 1, 1+16)
+		data[0] = byte(addrMemoryValue)
+		data = binary.BigEndian.AppendUint64(data, uint64(int64(address.SegmentIndex)))
+		data = binary.BigEndian.AppendUint64(data, address.Offset)
+		return data, nil
+	case unknownMemoryValue:
+		return []byte{byte(unknownMemoryValue)}, nil
+	default:
+		return nil, fmt.Errorf("unknown memory value kind: %d", mv.Kind)
+	}
+}
+
+// Decodes a memory value encoded by MarshalBinary
+func (mv *MemoryValue) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("cannot unmarshal memory value: empty input")
+	}
+
+	kind := memoryValueKind(data[0])
+	data = data[1:]
+	switch kind {
+	case feltMemoryValue:
+		if len(data) != f.Bytes {
+			return fmt.Errorf("cannot unmarshal felt: expected %d bytes, got %d", f.Bytes, len(data))
+		}
+		felt, err := f.BigEndian.Element((*[f.Bytes]byte)(data))
+		if err != nil {
+			return fmt.Errorf("cannot unmarshal felt: %w", err)
+		}
+		*mv = MemoryValueFromFieldElement(&felt)
+	case addrMemoryValue:
+		if len(data) != 16 {
+			return fmt.Errorf("cannot unmarshal address: expected 16 bytes, got %d", len(data))
+		}
+		*mv = MemoryValueFromMemoryAddress(&MemoryAddress{
+			SegmentIndex: int(int64(binary.BigEndian.Uint64(data[:8]))),
+			Offset:       binary.BigEndian.Uint64(data[8:]),
+		})
+	case unknownMemoryValue:
+		if len(data) != 0 {
+			return fmt.Errorf("cannot unmarshal unknown value: expected 0 bytes, got %d", len(data))
+		}
+		*mv = UnknownValue
+	default:
+		return fmt.Errorf("cannot unmarshal memory value: unknown kind %d", kind)
+	}
+	return nil
+}
+
+// JSON representation of a memory value, exactly one of the fields is set
+type memoryValueJSON struct {
+	Felt *string `json:"felt,omitempty"`
+	Ptr  *string `json:"ptr,omitempty"`
+}
+
+// Encodes the memory value as {"felt":"0x..."} for felts and {"ptr":"segment:offset"}
+// for addresses. Unknown values are encoded as null
+func (mv MemoryValue) MarshalJSON() ([]byte, error) {
+	switch mv.Kind {
+	case feltMemoryValue:
+		felt := "0x" + mv.Felt.Text(16)
+		return json.Marshal(memoryValueJSON{Felt: &felt})
+	case addrMemoryValue:
+		ptr := mv.addrUnsafe().String()
+		return json.Marshal(memoryValueJSON{Ptr: &ptr})
+	case unknownMemoryValue:
+		return []byte("null"), nil
+	default:
+		return nil, fmt.Errorf("unknown memory value kind: %d", mv.Kind)
+	}
+}
+
+// Decodes a memory value encoded by MarshalJSON. Felts can be written in any base
+// accepted by big.Int.SetString with a zero base, e.g. "0x1f" or "31"
+func (mv *MemoryValue) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*mv = UnknownValue
+		return nil
+	}
+
+	var value memoryValueJSON
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("cannot unmarshal memory value: %w", err)
+	}
+
+	switch {
+	case value.Felt != nil && value.Ptr != nil:
+		return errors.New("cannot unmarshal memory value: both felt and ptr are set")
+	case value.Felt != nil:
+		felt, ok := new(big.Int).SetString(*value.Felt, 0)
+		if !ok {
+			return fmt.Errorf("cannot unmarshal felt: invalid number %q", *value.Felt)
+		}
+		if felt.Sign() < 0 || felt.Cmp(f.Modulus()) >= 0 {
+			return fmt.Errorf("cannot unmarshal felt: %s is not in the field", felt)
+		}
+		*mv = MemoryValueFromFieldElement(new(f.Element).SetBigInt(felt))
+	case value.Ptr != nil:
+		segment, offset, found := strings.Cut(*value.Ptr, ":")
+		if !found {
+			return fmt.Errorf("cannot unmarshal address: expected segment:offset, got %q", *value.Ptr)
+		}
+		segmentIndex, err := strconv.Atoi(segment)
+		if err != nil {
+			return fmt.Errorf("cannot unmarshal address segment: %w", err)
+		}
+		offsetValue, err := strconv.ParseUint(offset, 10, 64)
+		if err != nil {
+			return fmt.Errorf("cannot unmarshal address offset: %w", err)
+		}
+		*mv = MemoryValueFromMemoryAddress(&MemoryAddress{SegmentIndex: segmentIndex, Offset: offsetValue})
+	default:
+		return errors.New("cannot unmarshal memory value: neither felt nor ptr is set")
+	}
+	return nil
+}
+
 // Returns a MemoryValue holding a felt as uint if it fits
 func (mv *MemoryValue) Uint64() (uint64, error) {
 	if mv.IsAddress() {
@@ -391,35 +633,55 @@ func (memory *Memory) GetConsecutiveMemoryValues(addr MemoryAddress, size uint64
 	return values, nil
 }
 
-func (memory *Memory) ResolveAsBigInt3(valAddr MemoryAddress) ([3]*f.Element, error) {
-	valMemoryValues, err := memory.GetConsecutiveMemoryValues(valAddr, uint64(3))
+// Writes the values to consecutive cells starting at addr. The address is only advanced
+// between writes, so the last value may land on the highest offset. It stops at the
+// first failing write, leaving the cells written before it untouched
+func (memory *Memory) WriteConsecutiveValues(addr MemoryAddress, values []MemoryValue) error {
+	for i := range values {
+		if i > 0 {
+			var err error
+			addr, err = addr.AddOffset(int16(1))
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := memory.WriteToAddress(&addr, &values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (memory *Memory) GetConsecutiveMemoryAddresses(addr MemoryAddress, size uint64) ([]MemoryAddress, error) {
+	values, err := memory.GetConsecutiveMemoryValues(addr, size)
 	if err != nil {
-		return [3]*f.Element{}, err
+		return nil, err
 	}
 
-	var valValues [3]*f.Element
-	for i := 0; i < 3; i++ {
-		valValue, err := valMemoryValues[i].FieldElement()
+	addresses := make([]MemoryAddress, size)
+	for i := range values {
+		address, err := values[i].MemoryAddress()
 		if err != nil {
-			return [3]*f.Element{}, err
+			return nil, fmt.Errorf("offset %d: %w", addr.Offset+uint64(i), err)
 		}
-		valValues[i] = valValue
+		addresses[i] = *address
 	}
-
-	return valValues, nil
+	return addresses, nil
 }
 
-func (memory *Memory) ResolveAsBigInt5(valAddr MemoryAddress) ([5]*f.Element, error) {
-	valMemoryValues, err := memory.GetConsecutiveMemoryValues(valAddr, uint64(5))
+// Reads n consecutive memory cells starting at valAddr, erroring if any of them is not a felt
+func (memory *Memory) ResolveAsBigIntN(valAddr MemoryAddress, n int) ([]*f.Element, error) {
+	valMemoryValues, err := memory.GetConsecutiveMemoryValues(valAddr, uint64(n))
 	if err != nil {
-		return [5]*f.Element{}, err
+		return nil, err
 	}
 
-	var valValues [5]*f.Element
-	for i := 0; i < 5; i++ {
+	valValues := make([]*f.Element, n)
+	for i := 0; i < n; i++ {
 		valValue, err := valMemoryValues[i].FieldElement()
 		if err != nil {
-			return [5]*f.Element{}, err
+			return nil, err
 		}
 		valValues[i] = valValue
 	}
@@ -427,20 +689,26 @@ func (memory *Memory) ResolveAsBigInt5(valAddr MemoryAddress) ([5]*f.Element, er
 	return valValues, nil
 }
 
-func (memory *Memory) ResolveAsEcPoint(valAddr MemoryAddress) ([2]*f.Element, error) {
-	valMemoryValues, err := memory.GetConsecutiveMemoryValues(valAddr, uint64(2))
+func (memory *Memory) ResolveAsBigInt3(valAddr MemoryAddress) ([3]*f.Element, error) {
+	valValues, err := memory.ResolveAsBigIntN(valAddr, 3)
 	if err != nil {
-		return [2]*f.Element{}, err
+		return [3]*f.Element{}, err
 	}
+	return [3]*f.Element(valValues), nil
+}
 
-	var valValues [2]*f.Element
-	for i := 0; i < 2; i++ {
-		valValue, err := valMemoryValues[i].FieldElement()
-		if err != nil {
-			return [2]*f.Element{}, err
-		}
-		valValues[i] = valValue
+func (memory *Memory) ResolveAsBigInt5(valAddr MemoryAddress) ([5]*f.Element, error) {
+	valValues, err := memory.ResolveAsBigIntN(valAddr, 5)
+	if err != nil {
+		return [5]*f.Element{}, err
 	}
+	return [5]*f.Element(valValues), nil
+}
 
-	return valValues, nil
+func (memory *Memory) ResolveAsEcPoint(valAddr MemoryAddress) ([2]*f.Element, error) {
+	valValues, err := memory.ResolveAsBigIntN(valAddr, 2)
+	if err != nil {
+		return [2]*f.Element{}, err
+	}
+	return [2]*f.Element(valValues), nil
 }
@@ -1,6 +1,10 @@
 package memory
 
 import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -146,7 +150,515 @@ func TestMemoryAddressSubMemoryAddressDiffSegment(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestMemoryAddressAddOffsetUnderflow(t *testing.T) {
+	address := MemoryAddress{SegmentIndex: 1, Offset: 0}
+
+	_, err := address.AddOffset(-1)
+	require.EqualError(t, err, "offset underflow: 0 - 1")
+}
+
+func TestMemoryAddressAddOffsetOverflow(t *testing.T) {
+	address := MemoryAddress{SegmentIndex: 1, Offset: math.MaxUint64}
+
+	_, err := address.AddOffset(1)
+	require.EqualError(t, err, "offset overflow: 18446744073709551615 + 1")
+}
+
+func TestDoubleAndSquareFelt(t *testing.T) {
+	felts := []MemoryValue{
+		MemoryValueFromInt(0),
+		MemoryValueFromInt(1),
+		MemoryValueFromInt(7),
+		MemoryValueFromInt(-3),
+		MemoryValueFromFieldElement(new(f.Element).SetUint64(math.MaxUint64)),
+	}
+
+	for _, felt := range felts {
+		doubled := EmptyMemoryValueAsFelt()
+		err := doubled.Double(&felt)
+		require.NoError(t, err)
+
+		added := EmptyMemoryValueAsFelt()
+		err = added.Add(&felt, &felt)
+		require.NoError(t, err)
+		assert.Equal(t, added, doubled)
+
+		squared := EmptyMemoryValueAsFelt()
+		err = squared.Square(&felt)
+		require.NoError(t, err)
+
+		multiplied := EmptyMemoryValueAsFelt()
+		err = multiplied.Mul(&felt, &felt)
+		require.NoError(t, err)
+		assert.Equal(t, multiplied, squared)
+	}
+}
+
+func TestDoubleAndSquareMemoryAddress(t *testing.T) {
+	address := MemoryValueFromSegmentAndOffset(2, 10)
+	memVal := EmptyMemoryValueAsFelt()
+
+	err := memVal.Double(&address)
+	assert.Error(t, err)
+
+	err = memVal.Square(&address)
+	assert.Error(t, err)
+}
+
+func TestMemoryValueFromBigInt(t *testing.T) {
+	// p + 5 is reduced to 5
+	aboveModulus := new(big.Int).Add(f.Modulus(), big.NewInt(5))
+	assert.Equal(t, MemoryValueFromInt(5), MemoryValueFromBigInt(aboveModulus))
+
+	// a negative value maps to its field equivalent
+	assert.Equal(t, MemoryValueFromInt(-7), MemoryValueFromBigInt(big.NewInt(-7)))
+
+	// the input is left untouched
+	input := big.NewInt(-7)
+	MemoryValueFromBigInt(input)
+	assert.Equal(t, int64(-7), input.Int64())
+}
+
+func TestMemoryValueFromAnyBigInt(t *testing.T) {
+	aboveModulus := new(big.Int).Add(f.Modulus(), big.NewInt(5))
+	value, err := MemoryValueFromAny(aboveModulus)
+	require.NoError(t, err)
+	assert.Equal(t, MemoryValueFromInt(5), value)
+
+	value, err = MemoryValueFromAny(big.NewInt(-7))
+	require.NoError(t, err)
+	assert.Equal(t, MemoryValueFromInt(-7), value)
+}
+
+func TestInvFelt(t *testing.T) {
+	one := MemoryValueFromInt(1)
+	memVal := EmptyMemoryValueAsFelt()
+	err := memVal.Inv(&one)
+	require.NoError(t, err)
+	assert.Equal(t, one, memVal)
+
+	seven := MemoryValueFromInt(7)
+	err = memVal.Inv(&seven)
+	require.NoError(t, err)
+	product := EmptyMemoryValueAsFelt()
+	err = product.Mul(&memVal, &seven)
+	require.NoError(t, err)
+	assert.Equal(t, one, product)
+
+	zero := MemoryValueFromInt(0)
+	err = memVal.Inv(&zero)
+	assert.EqualError(t, err, "cannot invert zero")
+
+	address := MemoryValueFromSegmentAndOffset(2, 10)
+	err = memVal.Inv(&address)
+	assert.EqualError(t, err, "cannot invert a memory address")
+}
+
+func TestNegFelt(t *testing.T) {
+	memVal := EmptyMemoryValueAsFelt()
+	zero := MemoryValueFromInt(0)
+
+	err := memVal.Neg(&zero)
+	require.NoError(t, err)
+	assert.Equal(t, MemoryValueFromInt(0), memVal)
+
+	value := MemoryValueFromInt(42)
+	negated := EmptyMemoryValueAsFelt()
+	err = negated.Neg(&value)
+	require.NoError(t, err)
+	assert.Equal(t, MemoryValueFromInt(-42), negated)
+
+	renegated := EmptyMemoryValueAsFelt()
+	err = renegated.Neg(&negated)
+	require.NoError(t, err)
+	assert.Equal(t, value, renegated)
+}
+
+func TestNegMemoryAddress(t *testing.T) {
+	memVal := EmptyMemoryValueAsFelt()
+	value := MemoryValueFromMemoryAddress(&MemoryAddress{
+		SegmentIndex: 2,
+		Offset:       10,
+	})
+
+	err := memVal.Neg(&value)
+	assert.Error(t, err)
+}
+
+func TestGetConsecutiveMemoryAddresses(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+
+	first := MemoryValueFromSegmentAndOffset(0, 5)
+	second := MemoryValueFromSegmentAndOffset(0, 7)
+	require.NoError(t, memory.Write(0, 1, &first))
+	require.NoError(t, memory.Write(0, 2, &second))
+	require.NoError(t, memory.Write(0, 3, memoryValuePointerFromInt(9)))
+
+	addresses, err := memory.GetConsecutiveMemoryAddresses(MemoryAddress{0, 1}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []MemoryAddress{{0, 5}, {0, 7}}, addresses)
+
+	_, err = memory.GetConsecutiveMemoryAddresses(MemoryAddress{0, 1}, 3)
+	assert.ErrorContains(t, err, "offset 3: memory value is not an address")
+}
+
+func TestWriteConsecutiveValues(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+
+	address := MemoryValueFromSegmentAndOffset(0, 9)
+	values := []MemoryValue{MemoryValueFromInt(1), address, MemoryValueFromInt(3)}
+	require.NoError(t, memory.WriteConsecutiveValues(MemoryAddress{0, 2}, values))
+
+	written, err := memory.GetConsecutiveMemoryValues(MemoryAddress{0, 2}, 3)
+	require.NoError(t, err)
+	assert.Equal(t, values, written)
+
+	// rewriting the same values is not a conflict
+	require.NoError(t, memory.WriteConsecutiveValues(MemoryAddress{0, 2}, values))
+}
+
+func TestWriteConsecutiveValuesConflict(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+	require.NoError(t, memory.Write(0, 2, memoryValuePointerFromInt(7)))
+
+	values := []MemoryValue{MemoryValueFromInt(4), MemoryValueFromInt(5), MemoryValueFromInt(6), MemoryValueFromInt(8)}
+	err := memory.WriteConsecutiveValues(MemoryAddress{0, 0}, values)
+	assert.ErrorContains(t, err, "segment 0, offset 2: rewriting value")
+
+	// the writes before the conflict remain
+	written, err := memory.GetConsecutiveMemoryValues(MemoryAddress{0, 0}, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []MemoryValue{MemoryValueFromInt(4), MemoryValueFromInt(5), MemoryValueFromInt(7)}, written)
+
+	// and the ones after it were never made
+	cell, err := memory.Peek(0, 3)
+	require.NoError(t, err)
+	assert.False(t, cell.Known())
+}
+
+type boundedTestBuiltin struct {
+	NoBuiltin
+	capacity uint64
+}
+
+func (b *boundedTestBuiltin) Capacity() (uint64, bool) {
+	return b.capacity, true
+}
+
+func TestWriteConsecutiveValuesUpToBound(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	segment := memory.AllocateBuiltinSegment(&boundedTestBuiltin{capacity: 3})
+
+	// the last value lands on the last cell the segment can hold
+	values := []MemoryValue{MemoryValueFromInt(1), MemoryValueFromInt(2), MemoryValueFromInt(3)}
+	require.NoError(t, memory.WriteConsecutiveValues(segment, values))
+
+	written, err := memory.GetConsecutiveMemoryValues(segment, 3)
+	require.NoError(t, err)
+	assert.Equal(t, values, written)
+
+	// one more value goes past it
+	values = append(values, MemoryValueFromInt(4))
+	err = memory.WriteConsecutiveValues(segment, values)
+	assert.ErrorContains(t, err, "offset 3 is out of bounds, segment capacity is 3")
+}
+
+func TestResolveAsBigIntN(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, memory.Write(0, uint64(i), memoryValuePointerFromInt(i+1)))
+	}
+
+	values, err := memory.ResolveAsBigIntN(MemoryAddress{0, 0}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*f.Element{new(f.Element).SetUint64(1)}, values)
+
+	values, err = memory.ResolveAsBigIntN(MemoryAddress{0, 2}, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []*f.Element{
+		new(f.Element).SetUint64(3),
+		new(f.Element).SetUint64(4),
+		new(f.Element).SetUint64(5),
+	}, values)
+
+	bigInt3, err := memory.ResolveAsBigInt3(MemoryAddress{0, 2})
+	require.NoError(t, err)
+	assert.Equal(t, [3]*f.Element(values), bigInt3)
+
+	// reading past the written cells reaches unknown memory
+	_, err = memory.ResolveAsBigIntN(MemoryAddress{0, 0}, 7)
+	assert.ErrorContains(t, err, "reading unknown value")
+}
+
+func TestResolveAsBigIntNAddress(t *testing.T) {
+	memory := InitializeEmptyMemory()
+	memory.AllocateEmptySegment()
+
+	addr := MemoryValueFromSegmentAndOffset(0, 0)
+	require.NoError(t, memory.Write(0, 0, memoryValuePointerFromInt(1)))
+	require.NoError(t, memory.Write(0, 1, &addr))
+
+	_, err := memory.ResolveAsBigIntN(MemoryAddress{0, 0}, 2)
+	assert.ErrorContains(t, err, "memory value is not a field element")
+}
+
 func memoryValuePointerFromInt[T constraints.Integer](v T) *MemoryValue {
 	mv := MemoryValueFromInt(v)
 	return &mv
 }
+
+func TestIsZeroAndIsOne(t *testing.T) {
+	zero := MemoryValueFromInt(0)
+	one := MemoryValueFromInt(1)
+	assert.True(t, zero.IsZero())
+	assert.False(t, zero.IsOne())
+	assert.False(t, one.IsZero())
+	assert.True(t, one.IsOne())
+
+	// an address is never zero nor one, even when its underlying value is
+	address := MemoryValueFromSegmentAndOffset(0, 1)
+	assert.False(t, address.IsZero())
+	assert.False(t, address.IsOne())
+
+	assert.False(t, UnknownValue.IsZero())
+	assert.False(t, UnknownValue.IsOne())
+}
+
+func TestExpectFeltAndAddress(t *testing.T) {
+	felt := MemoryValueFromInt(5)
+	address := MemoryValueFromSegmentAndOffset(2, 3)
+
+	feltValue, err := felt.ExpectFelt("lhs")
+	require.NoError(t, err)
+	assert.Equal(t, new(f.Element).SetUint64(5), feltValue)
+
+	addressValue, err := address.ExpectAddress("ptr")
+	require.NoError(t, err)
+	assert.Equal(t, &MemoryAddress{2, 3}, addressValue)
+
+	_, err = address.ExpectFelt("lhs")
+	assert.EqualError(t, err, "lhs: memory value is not a field element")
+
+	_, err = felt.ExpectAddress("ptr")
+	assert.EqualError(t, err, "ptr: memory value is not an address")
+}
+
+func TestCompareMemoryAddresses(t *testing.T) {
+	addresses := []MemoryAddress{
+		{2, 0},
+		{0, 7},
+		{1, 3},
+		{0, 2},
+		{2, 5},
+		{1, 3},
+		{1, 0},
+	}
+
+	slices.SortStableFunc(addresses, CompareMemoryAddresses)
+	assert.Equal(t, []MemoryAddress{
+		{0, 2},
+		{0, 7},
+		{1, 0},
+		{1, 3},
+		{1, 3},
+		{2, 0},
+		{2, 5},
+	}, addresses)
+
+	assert.Equal(t, 0, CompareMemoryAddresses(MemoryAddress{1, 3}, MemoryAddress{1, 3}))
+	assert.Equal(t, -1, CompareMemoryAddresses(MemoryAddress{0, 9}, MemoryAddress{1, 0}))
+	assert.Equal(t, 1, CompareMemoryAddresses(MemoryAddress{1, 1}, MemoryAddress{1, 0}))
+}
+
+func TestMemoryValueMarshalBinary(t *testing.T) {
+	tests := []struct {
+		name  string
+		value MemoryValue
+		size  int
+	}{
+		{
+			name:  "felt",
+			value: MemoryValueFromInt(-1),
+			size:  33,
+		},
+		{
+			name:  "address",
+			value: MemoryValueFromSegmentAndOffset(3, 1<<40),
+			size:  17,
+		},
+		{
+			name:  "temporary segment address",
+			value: MemoryValueFromSegmentAndOffset(-2, 7),
+			size:  17,
+		},
+		{
+			name:  "unknown",
+			value: UnknownValue,
+			size:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.value.MarshalBinary()
+			require.NoError(t, err)
+			assert.Len(t, data, tt.size)
+
+			var decoded MemoryValue
+			require.NoError(t, decoded.UnmarshalBinary(data))
+			assert.Equal(t, tt.value, decoded)
+		})
+	}
+}
+
+func TestMemoryValueMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		value MemoryValue
+		json  string
+	}{
+		{
+			name:  "felt",
+			value: MemoryValueFromInt(255),
+			json:  `{"felt":"0xff"}`,
+		},
+		{
+			name:  "address",
+			value: MemoryValueFromSegmentAndOffset(2, 5),
+			json:  `{"ptr":"2:5"}`,
+		},
+		{
+			name:  "temporary segment address",
+			value: MemoryValueFromSegmentAndOffset(-1, 3),
+			json:  `{"ptr":"-1:3"}`,
+		},
+		{
+			name:  "unknown",
+			value: UnknownValue,
+			json:  `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(&tt.value)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(data))
+
+			var decoded MemoryValue
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, tt.value, decoded)
+		})
+	}
+}
+
+func TestMemoryValueMarshalByValue(t *testing.T) {
+	values := []MemoryValue{
+		MemoryValueFromInt(255),
+		MemoryValueFromSegmentAndOffset(2, 5),
+		UnknownValue,
+	}
+
+	data, err := json.Marshal(values)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"felt":"0xff"},{"ptr":"2:5"},null]`, string(data))
+
+	var decoded []MemoryValue
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, values, decoded)
+
+	binary, err := MemoryValueFromInt(255).MarshalBinary()
+	require.NoError(t, err)
+	var decodedBinary MemoryValue
+	require.NoError(t, decodedBinary.UnmarshalBinary(binary))
+	assert.Equal(t, MemoryValueFromInt(255), decodedBinary)
+}
+
+func TestMemoryValueUnmarshalJSONInvalid(t *testing.T) {
+	var decoded MemoryValue
+	assert.EqualError(
+		t,
+		json.Unmarshal([]byte(`{"felt":"0x1","ptr":"1:2"}`), &decoded),
+		"cannot unmarshal memory value: both felt and ptr are set",
+	)
+	assert.EqualError(
+		t,
+		json.Unmarshal([]byte(`{}`), &decoded),
+		"cannot unmarshal memory value: neither felt nor ptr is set",
+	)
+	assert.EqualError(
+		t,
+		json.Unmarshal([]byte(`{"ptr":"12"}`), &decoded),
+		`cannot unmarshal address: expected segment:offset, got "12"`,
+	)
+}
+
+func TestMemoryValueUnmarshalBinaryTruncated(t *testing.T) {
+	felt := MemoryValueFromInt(42)
+	feltData, err := felt.MarshalBinary()
+	require.NoError(t, err)
+
+	address := MemoryValueFromSegmentAndOffset(1, 2)
+	addressData, err := address.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded MemoryValue
+	assert.EqualError(t, decoded.UnmarshalBinary(nil), "cannot unmarshal memory value: empty input")
+	assert.EqualError(
+		t,
+		decoded.UnmarshalBinary(feltData[:len(feltData)-1]),
+		"cannot unmarshal felt: expected 32 bytes, got 31",
+	)
+	assert.EqualError(
+		t,
+		decoded.UnmarshalBinary(addressData[:9]),
+		"cannot unmarshal address: expected 16 bytes, got 8",
+	)
+}
+
+func TestMemoryValueCmp(t *testing.T) {
+	lhs := MemoryValueFromInt(3)
+	rhs := MemoryValueFromInt(7)
+
+	res, err := lhs.Cmp(&rhs)
+	require.NoError(t, err)
+	assert.Equal(t, -1, res)
+
+	res, err = rhs.Cmp(&lhs)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res)
+
+	res, err = lhs.Cmp(&lhs)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res)
+}
+
+func TestMemoryValueCmpAddress(t *testing.T) {
+	lhs := MemoryValueFromSegmentAndOffset(2, 10)
+	rhs := MemoryValueFromSegmentAndOffset(2, 10)
+
+	res, err := lhs.Cmp(&rhs)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res)
+
+	rhs = MemoryValueFromSegmentAndOffset(3, 0)
+	res, err = lhs.Cmp(&rhs)
+	require.NoError(t, err)
+	assert.Equal(t, -1, res)
+}
+
+func TestMemoryValueCmpFeltAndAddress(t *testing.T) {
+	lhs := MemoryValueFromInt(3)
+	rhs := MemoryValueFromSegmentAndOffset(2, 10)
+
+	_, err := lhs.Cmp(&rhs)
+	assert.ErrorContains(t, err, "cannot compare memory values of different kinds")
+
+	_, err = rhs.Cmp(&lhs)
+	assert.Error(t, err)
+}
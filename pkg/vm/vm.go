@@ -557,29 +557,8 @@ func (vm *VirtualMachine) RelocateTrace(relocatedTrace *[]Trace) {
 // It returns all segments in memory but relocated as a single segment
 // Each element is a pointer to a field element, if the cell was not accessed,
 // nil is stored instead
-func (vm *VirtualMachine) RelocateMemory() ([]*f.Element, []uint64) {
-	segmentsOffsets, maxMemoryUsed := vm.Memory.RelocationOffsets()
-	// the prover expect first element of the relocated memory to start at index 1,
-	// this way we fill relocatedMemory starting from zero, but the actual value
-	// returned has nil as its first element.
-	relocatedMemory := make([]*f.Element, maxMemoryUsed)
-	for i, segment := range vm.Memory.Segments {
-		for j := uint64(0); j < segment.RealLen(); j++ {
-			if !segment.Data[j].Known() {
-				continue
-			}
-
-			var felt *f.Element
-			if segment.Data[j].IsAddress() {
-				addr, _ := segment.Data[j].MemoryAddress()
-				felt = addr.Relocate(segmentsOffsets)
-			} else {
-				felt, _ = segment.Data[j].FieldElement()
-			}
-			relocatedMemory[segmentsOffsets[i]+j] = felt
-		}
-	}
-	return relocatedMemory, segmentsOffsets
+func (vm *VirtualMachine) RelocateMemory() ([]*f.Element, []uint64, error) {
+	return vm.Memory.RelocateSparse()
 }
 
 const ctxSize = 3 * 8
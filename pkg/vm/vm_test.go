@@ -1153,7 +1153,8 @@ func TestMemoryRelocationWithFelt(t *testing.T) {
 		},
 	)
 
-	res, _ := vm.RelocateMemory()
+	res, _, err := vm.RelocateMemory()
+	require.NoError(t, err)
 
 	expected := []*f.Element{
 		nil,
@@ -1214,7 +1215,8 @@ func TestMemoryRelocationWithAddress(t *testing.T) {
 		},
 	)
 
-	res, _ := vm.RelocateMemory()
+	res, _, err := vm.RelocateMemory()
+	require.NoError(t, err)
 
 	expected := []*f.Element{
 		nil,